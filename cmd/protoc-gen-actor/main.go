@@ -0,0 +1,161 @@
+// protoc-gen-actor generates Go code for every proto service marked with the
+// ActorKind option: a handler interface actors of the kind implement, a
+// dispatch registry and registration function built on it, and a client
+// sending the messages of the service through a manager.
+//
+// The code for a file goes to the actorpb package next to the package of the
+// file. It only depends on the manager through the model.Asker and
+// model.KindRegistry interfaces, so packages the manager depends on can use it.
+package main
+
+import (
+	"fmt"
+	"path"
+
+	"github.com/gnarloqgames/ga-actor-poc/message"
+	"google.golang.org/protobuf/compiler/protogen"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/pluginpb"
+)
+
+const (
+	packageName = "actorpb"
+
+	contextPackage  = protogen.GoImportPath("context")
+	fmtPackage      = protogen.GoImportPath("fmt")
+	timePackage     = protogen.GoImportPath("time")
+	dispatchPackage = protogen.GoImportPath("github.com/gnarloqgames/ga-actor-poc/internal/dispatch")
+	modelPackage    = protogen.GoImportPath("github.com/gnarloqgames/ga-actor-poc/internal/model")
+)
+
+func main() {
+	protogen.Options{}.Run(func(gen *protogen.Plugin) error {
+		gen.SupportedFeatures = uint64(pluginpb.CodeGeneratorResponse_FEATURE_PROTO3_OPTIONAL)
+
+		for _, f := range gen.Files {
+			if f.Generate {
+				generateFile(gen, f)
+			}
+		}
+
+		return nil
+	})
+}
+
+// actorKind returns the kind a service is marked with, or an empty string.
+func actorKind(service *protogen.Service) string {
+	kind, _ := proto.GetExtension(service.Desc.Options(), message.E_ActorKind).(string)
+
+	return kind
+}
+
+func generateFile(gen *protogen.Plugin, file *protogen.File) {
+	services := make([]*protogen.Service, 0, len(file.Services))
+	for _, service := range file.Services {
+		if actorKind(service) != "" {
+			services = append(services, service)
+		}
+	}
+	if len(services) == 0 {
+		return
+	}
+
+	prefix := file.GeneratedFilenamePrefix
+	filename := path.Join(path.Dir(prefix), packageName, path.Base(prefix)+"_actor.pb.go")
+	g := gen.NewGeneratedFile(filename, file.GoImportPath+"/"+packageName)
+
+	g.P("// Code generated by protoc-gen-actor. DO NOT EDIT.")
+	g.P("// source: ", file.Desc.Path())
+	g.P()
+	g.P("package ", packageName)
+	g.P()
+
+	for _, service := range services {
+		generateService(g, service)
+	}
+}
+
+func generateService(g *protogen.GeneratedFile, service *protogen.Service) {
+	name := service.GoName
+	kind := actorKind(service)
+
+	ctx := g.QualifiedGoIdent(contextPackage.Ident("Context"))
+	actor := g.QualifiedGoIdent(modelPackage.Ident("Actor"))
+	address := g.QualifiedGoIdent(modelPackage.Ident("Address"))
+	asker := g.QualifiedGoIdent(modelPackage.Ident("Asker"))
+	registry := g.QualifiedGoIdent(modelPackage.Ident("KindRegistry"))
+	sendOption := g.QualifiedGoIdent(modelPackage.Ident("SendOption"))
+
+	g.P("// ", name, "Kind is the actor kind serving the ", name, " service.")
+	g.P("const ", name, "Kind = ", fmt.Sprintf("%q", kind))
+	g.P()
+
+	g.P("// ", name, "Handler is implemented by actors of the ", kind, " kind. Responses are")
+	g.P("// nil when the sender does not expect one.")
+	g.P("type ", name, "Handler interface {")
+	for _, method := range service.Methods {
+		g.P(method.GoName, "(ctx ", ctx, ", req *", method.Input.GoIdent, ", res *", method.Output.GoIdent, ") error")
+	}
+	g.P("}")
+	g.P()
+
+	g.P("// New", name, "Registry returns a registry dispatching the messages of the")
+	g.P("// ", name, " service to the methods of A.")
+	g.P("func New", name, "Registry[A ", name, "Handler]() *", dispatchPackage.Ident("Registry"), "[A] {")
+	g.P("r := ", dispatchPackage.Ident("NewRegistry"), "[A](", name, "Kind)")
+	for _, method := range service.Methods {
+		g.P(dispatchPackage.Ident("Handle"), "(r, func(a A, ctx ", ctx, ", req *", method.Input.GoIdent, ", res *", method.Output.GoIdent, ") error {")
+		g.P("return a.", method.GoName, "(ctx, req, res)")
+		g.P("})")
+	}
+	g.P()
+	g.P("return r")
+	g.P("}")
+	g.P()
+
+	g.P("// Register", name, " registers factory with r as the ", kind, " kind.")
+	g.P("func Register", name, "[A interface {")
+	g.P(actor)
+	g.P(name, "Handler")
+	g.P("}](r ", registry, ", factory func(ctx ", ctx, ") A) error {")
+	g.P("return r.NewKind(", name, "Kind, func(ctx ", ctx, ") ", actor, " {")
+	g.P("return factory(ctx)")
+	g.P("})")
+	g.P("}")
+	g.P()
+
+	g.P("// ", name, "Client sends the messages of the ", name, " service to ", kind)
+	g.P("// actors through a manager.")
+	g.P("type ", name, "Client struct {")
+	g.P("asker   ", asker)
+	g.P("timeout ", timePackage.Ident("Duration"))
+	g.P("}")
+	g.P()
+
+	g.P("// New", name, "Client returns a client whose requests must be handled within")
+	g.P("// timeout.")
+	g.P("func New", name, "Client(a ", asker, ", timeout ", timePackage.Ident("Duration"), ") *", name, "Client {")
+	g.P("return &", name, "Client{")
+	g.P("asker:   a,")
+	g.P("timeout: timeout,")
+	g.P("}")
+	g.P("}")
+	g.P()
+
+	for _, method := range service.Methods {
+		g.P("// ", method.GoName, " asks the ", kind, " actor at address to handle req.")
+		g.P("func (c *", name, "Client) ", method.GoName, "(ctx ", ctx, ", address ", address, ", req *", method.Input.GoIdent, ", opts ...", sendOption, ") (*", method.Output.GoIdent, ", error) {")
+		g.P("if address.Kind != ", name, "Kind {")
+		g.P("return nil, ", fmtPackage.Ident("Errorf"), "(\"address kind %s is not %s\", address.Kind, ", name, "Kind)")
+		g.P("}")
+		g.P()
+		g.P("res := &", method.Output.GoIdent, "{}")
+		g.P("if err := c.asker.Ask(ctx, address, req, res, c.timeout, opts...); err != nil {")
+		g.P("return nil, err")
+		g.P("}")
+		g.P()
+		g.P("return res, nil")
+		g.P("}")
+		g.P()
+	}
+}
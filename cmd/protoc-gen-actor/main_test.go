@@ -0,0 +1,100 @@
+package main
+
+import (
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/gnarloqgames/ga-actor-poc/message"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/compiler/protogen"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/pluginpb"
+)
+
+// request builds the request protoc sends for the given files, including every
+// file they import.
+func request(files ...protoreflect.FileDescriptor) *pluginpb.CodeGeneratorRequest {
+	req := &pluginpb.CodeGeneratorRequest{
+		Parameter: proto.String("paths=source_relative"),
+	}
+
+	seen := make(map[string]bool)
+	var add func(fd protoreflect.FileDescriptor)
+	add = func(fd protoreflect.FileDescriptor) {
+		if seen[fd.Path()] {
+			return
+		}
+		seen[fd.Path()] = true
+
+		for i := 0; i < fd.Imports().Len(); i++ {
+			add(fd.Imports().Get(i).FileDescriptor)
+		}
+		req.ProtoFile = append(req.ProtoFile, protodesc.ToFileDescriptorProto(fd))
+	}
+
+	for _, fd := range files {
+		add(fd)
+		req.FileToGenerate = append(req.FileToGenerate, fd.Path())
+	}
+
+	return req
+}
+
+func generate(t *testing.T, req *pluginpb.CodeGeneratorRequest) []*pluginpb.CodeGeneratorResponse_File {
+	t.Helper()
+
+	gen, err := protogen.Options{}.New(req)
+	require.NoError(t, err)
+
+	for _, f := range gen.Files {
+		if f.Generate {
+			generateFile(gen, f)
+		}
+	}
+
+	res := gen.Response()
+	require.Nil(t, res.Error)
+
+	return res.File
+}
+
+// withoutImports drops the import block, which goimports regroups after
+// generation.
+func withoutImports(src string) string {
+	start := strings.Index(src, "import (")
+	end := strings.Index(src[start:], "\n)\n") + start
+
+	return src[:start] + src[end+3:]
+}
+
+func TestGeneratedUpToDate(t *testing.T) {
+	files := generate(t, request(message.File_application_proto))
+	require.Len(t, files, 1)
+	require.Equal(t, "actorpb/application_actor.pb.go", files[0].GetName())
+
+	existing, err := os.ReadFile("../../message/actorpb/application_actor.pb.go")
+	require.NoError(t, err)
+
+	require.Equal(t, withoutImports(string(existing)), withoutImports(files[0].GetContent()), "run message/build.sh")
+}
+
+func TestSkipsUnmarkedServices(t *testing.T) {
+	file := protodesc.ToFileDescriptorProto(message.File_actor_proto)
+	file.Service = []*descriptorpb.ServiceDescriptorProto{{
+		Name: proto.String("Plain"),
+		Method: []*descriptorpb.MethodDescriptorProto{{
+			Name:       proto.String("Resolve"),
+			InputType:  proto.String(".message.Address"),
+			OutputType: proto.String(".message.Address"),
+		}},
+	}}
+
+	req := request(message.File_actor_proto)
+	req.ProtoFile[len(req.ProtoFile)-1] = file
+
+	require.Empty(t, generate(t, req))
+}
@@ -0,0 +1,183 @@
+package actor
+
+import (
+	"slices"
+	"sync"
+
+	"github.com/google/uuid"
+)
+
+// Storeable is any value a Collection can hold.
+type Storeable interface {
+	GetID() uuid.UUID
+}
+
+// Collection stores items by their ID. Secondary indexes registered with Index
+// allow looking items up by other keys.
+type Collection[T Storeable] struct {
+	mx *sync.Mutex
+
+	items   map[uuid.UUID]T
+	indexes map[string]*index[T]
+}
+
+type index[T Storeable] struct {
+	key     func(T) string
+	entries map[string][]uuid.UUID
+}
+
+func (i *index[T]) add(item T) {
+	key := i.key(item)
+	i.entries[key] = append(i.entries[key], item.GetID())
+}
+
+func (i *index[T]) remove(item T) {
+	key := i.key(item)
+
+	entries := slices.DeleteFunc(i.entries[key], func(id uuid.UUID) bool {
+		return id == item.GetID()
+	})
+	if len(entries) == 0 {
+		delete(i.entries, key)
+		return
+	}
+
+	i.entries[key] = entries
+}
+
+func NewCollection[T Storeable]() *Collection[T] {
+	return &Collection[T]{
+		mx: &sync.Mutex{},
+
+		items:   make(map[uuid.UUID]T),
+		indexes: make(map[string]*index[T]),
+	}
+}
+
+// Index registers a secondary index that maps every item to the value key
+// returns for it. Items already in the collection are indexed immediately.
+func (c *Collection[T]) Index(name string, key func(T) string) {
+	c.mx.Lock()
+	defer c.mx.Unlock()
+
+	idx := &index[T]{
+		key:     key,
+		entries: make(map[string][]uuid.UUID),
+	}
+	for _, item := range c.items {
+		idx.add(item)
+	}
+
+	c.indexes[name] = idx
+}
+
+func (c *Collection[T]) Get(id uuid.UUID) (T, bool) {
+	c.mx.Lock()
+	defer c.mx.Unlock()
+
+	item, ok := c.items[id]
+
+	return item, ok
+}
+
+// Lookup returns the items whose key in the named index equals key, in the
+// order they were first stored.
+func (c *Collection[T]) Lookup(name string, key string) []T {
+	c.mx.Lock()
+	defer c.mx.Unlock()
+
+	idx, ok := c.indexes[name]
+	if !ok {
+		return nil
+	}
+
+	items := make([]T, 0, len(idx.entries[key]))
+	for _, id := range idx.entries[key] {
+		items = append(items, c.items[id])
+	}
+
+	return items
+}
+
+// First returns the first item Lookup would return.
+func (c *Collection[T]) First(name string, key string) (T, bool) {
+	items := c.Lookup(name, key)
+	if len(items) == 0 {
+		var zero T
+		return zero, false
+	}
+
+	return items[0], true
+}
+
+// Set stores item under its ID, replacing any previous item with the same ID.
+// A replaced item keeps its place in the indexes whose key did not change.
+func (c *Collection[T]) Set(item T) {
+	c.mx.Lock()
+	defer c.mx.Unlock()
+
+	previous, replaced := c.items[item.GetID()]
+	for _, idx := range c.indexes {
+		if replaced {
+			if idx.key(previous) == idx.key(item) {
+				continue
+			}
+			idx.remove(previous)
+		}
+		idx.add(item)
+	}
+
+	c.items[item.GetID()] = item
+}
+
+func (c *Collection[T]) Delete(id uuid.UUID) {
+	c.mx.Lock()
+	defer c.mx.Unlock()
+
+	item, ok := c.items[id]
+	if !ok {
+		return
+	}
+
+	for _, idx := range c.indexes {
+		idx.remove(item)
+	}
+
+	delete(c.items, id)
+}
+
+// Find returns the first item for which match reports true. Iteration order
+// is unspecified.
+func (c *Collection[T]) Find(match func(T) bool) (T, bool) {
+	c.mx.Lock()
+	defer c.mx.Unlock()
+
+	for _, item := range c.items {
+		if match(item) {
+			return item, true
+		}
+	}
+
+	var zero T
+	return zero, false
+}
+
+// All returns every item. Iteration order is unspecified.
+func (c *Collection[T]) All() []T {
+	c.mx.Lock()
+	defer c.mx.Unlock()
+
+	items := make([]T, 0, len(c.items))
+	for _, item := range c.items {
+		items = append(items, item)
+	}
+
+	return items
+}
+
+func (c *Collection[T]) Len() int {
+	c.mx.Lock()
+	defer c.mx.Unlock()
+
+	return len(c.items)
+}
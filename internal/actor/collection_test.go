@@ -0,0 +1,99 @@
+package actor
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCollectionIndex(t *testing.T) {
+	collection := NewCollection[Resource]()
+	wood := Resource{id: uuid.New(), name: "wood", amount: 1}
+	collection.Set(wood)
+
+	collection.Index(IndexName, func(r Resource) string { return r.name })
+
+	stone := Resource{id: uuid.New(), name: "stone", amount: 2}
+	moreWood := Resource{id: uuid.New(), name: "wood", amount: 3}
+	collection.Set(stone)
+	collection.Set(moreWood)
+
+	tests := []struct {
+		label    string
+		mutate   func()
+		key      string
+		expected []uuid.UUID
+	}{
+		{
+			label:    "indexed before registration",
+			mutate:   func() {},
+			key:      "wood",
+			expected: []uuid.UUID{wood.id, moreWood.id},
+		},
+		{
+			label: "updated",
+			mutate: func() {
+				wood.amount = 10
+				collection.Set(wood)
+			},
+			key:      "wood",
+			expected: []uuid.UUID{wood.id, moreWood.id},
+		},
+		{
+			label: "renamed",
+			mutate: func() {
+				stone.name = "marble"
+				collection.Set(stone)
+			},
+			key:      "stone",
+			expected: []uuid.UUID{},
+		},
+		{
+			label: "renamed into key",
+			mutate: func() {
+				stone.name = "wood"
+				collection.Set(stone)
+			},
+			key:      "wood",
+			expected: []uuid.UUID{wood.id, moreWood.id, stone.id},
+		},
+		{
+			label: "deleted",
+			mutate: func() {
+				collection.Delete(moreWood.id)
+			},
+			key:      "wood",
+			expected: []uuid.UUID{wood.id, stone.id},
+		},
+		{
+			label:    "missing",
+			mutate:   func() {},
+			key:      "gold",
+			expected: []uuid.UUID{},
+		},
+	}
+
+	for _, tt := range tests {
+		tf := func(t *testing.T) {
+			tt.mutate()
+
+			actual := make([]uuid.UUID, 0)
+			for _, item := range collection.Lookup(IndexName, tt.key) {
+				actual = append(actual, item.id)
+			}
+			require.Equal(t, tt.expected, actual)
+
+			first, ok := collection.First(IndexName, tt.key)
+			require.Equal(t, len(tt.expected) > 0, ok)
+			if ok {
+				require.Equal(t, tt.expected[0], first.id)
+			}
+		}
+
+		t.Run(tt.label, tf)
+	}
+
+	require.Nil(t, collection.Lookup("unknown", "wood"))
+	require.Equal(t, 2, collection.Len())
+}
@@ -1,19 +1,43 @@
 package actor
 
 import (
+	"cmp"
 	"context"
+	"errors"
 	"fmt"
 	"log/slog"
+	"slices"
+	"strings"
 	"sync"
 	"time"
 
+	"github.com/gnarloqgames/ga-actor-poc/internal/clock"
+	"github.com/gnarloqgames/ga-actor-poc/internal/dispatch"
+	"github.com/gnarloqgames/ga-actor-poc/internal/executor"
 	"github.com/gnarloqgames/ga-actor-poc/internal/model"
+	"github.com/gnarloqgames/ga-actor-poc/internal/modifier"
 	"github.com/gnarloqgames/ga-actor-poc/message"
+	"github.com/gnarloqgames/ga-actor-poc/message/actorpb"
 	"github.com/google/uuid"
 	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
-var _ model.Actor = (*InventoryActor)(nil)
+var (
+	_ model.Actor              = (*InventoryActor)(nil)
+	_ model.QueueReporter      = (*InventoryActor)(nil)
+	_ model.Inspector          = (*InventoryActor)(nil)
+	_ actorpb.InventoryHandler = (*InventoryActor)(nil)
+)
+
+var inventoryHandlers = actorpb.NewInventoryRegistry[*InventoryActor]()
+
+func init() {
+	dispatch.Handle(inventoryHandlers, (*InventoryActor).ChildTerminated)
+	dispatch.Handle(inventoryHandlers, (*InventoryActor).BuildTimerFired)
+	dispatch.Handle(inventoryHandlers, (*InventoryActor).CraftTimerFired)
+}
 
 type Building struct {
 	id   uuid.UUID
@@ -33,118 +57,134 @@ type StoredResource struct {
 	temporary bool
 }
 
-type Storeable interface {
-	Building | Resource | StoredResource
-}
-
-type Collection[T Storeable] struct {
-	mx *sync.Mutex
-
-	items map[uuid.UUID]T
-}
+const (
+	IndexName string = "name"
 
-func NewCollection[T Storeable]() *Collection[T] {
-	return &Collection[T]{
-		mx: &sync.Mutex{},
+	// TopicInventory is the topic inventories publish their events on.
+	TopicInventory string = "inventory"
 
-		items: make(map[uuid.UUID]T),
-	}
-}
+	QueueBuild string = "build"
+	QueueCraft string = "craft"
+)
 
-type Queueable interface {
-	*message.BuildRequest
+func (b Building) GetID() uuid.UUID {
+	return b.id
 }
 
-type Queue[T Queueable] struct {
-	mx *sync.Mutex
-
-	indices []uuid.UUID
-	items   map[uuid.UUID]T
+func (r Resource) GetID() uuid.UUID {
+	return r.id
 }
 
-func NewQueue[T Queueable]() *Queue[T] {
-	return &Queue[T]{
-		mx: &sync.Mutex{},
-
-		indices: make([]uuid.UUID, 0),
-		items:   make(map[uuid.UUID]T),
-	}
+func (r StoredResource) GetID() uuid.UUID {
+	return r.id
 }
 
-func (q *Queue[T]) len() int {
-	return len(q.indices)
+// activeTimer times the build or craft in progress. A restored inventory has
+// no timer until resume starts it.
+type activeTimer struct {
+	timer  *TimerActor
+	fireAt time.Time
+	// wake is the durable timer activating the inventory again at fireAt if
+	// it is passivated in the meantime.
+	wake uuid.UUID
 }
 
-func (q *Queue[T]) Unshift() T {
-	q.mx.Lock()
-	defer q.mx.Unlock()
-
-	len := q.len()
-	if len == 0 {
-		return nil
+// status returns the state of the timer, as of now if it has not started.
+func (t *activeTimer) status(now time.Time) TimerReply {
+	if t.timer == nil {
+		return TimerReply{Status: StatusRunning, Remaining: max(t.fireAt.Sub(now), 0)}
 	}
 
-	index := q.indices[0]
-	val := q.items[index]
-
-	if len == 1 {
-		q.indices = make([]uuid.UUID, 0)
-	} else {
-		q.indices = q.indices[1:]
-	}
+	return t.timer.Status()
+}
 
-	delete(q.items, index)
+type activeBuild struct {
+	activeTimer
 
-	return val
+	request *message.BuildRequest
 }
 
-func (q *Queue[T]) Push(item T) int {
-	q.mx.Lock()
-	defer q.mx.Unlock()
+type activeCraft struct {
+	activeTimer
 
-	index := uuid.New()
-	q.indices = append(q.indices, index)
-	q.items[index] = item
-
-	return q.len()
+	request *message.CraftRequest
+	recipe  Recipe
 }
 
 type InventoryActor struct {
 	ID uuid.UUID
 
-	timers map[uuid.UUID]time.Timer
+	actx    model.ActorContext
+	mx      *sync.Mutex
+	current *activeBuild
+	// spedUp holds the remaining durations, modifiers included, of the
+	// queued builds that were sped up.
+	spedUp map[string]time.Duration
+	// costs holds what was debited for the queued builds, refunded if they
+	// cannot start.
+	costs    map[string]map[string]uint64
+	crafting *activeCraft
+	// queuedCrafts holds the recipes of the queued crafts, whose inputs are
+	// already debited.
+	queuedCrafts map[string]Recipe
+	stopped      bool
 
 	Buildings *Collection[Building]
 	Resources *Collection[Resource]
+	Modifiers *modifier.Registry
+	Recipes   *RecipeBook
+	Clock     clock.Clock
+	Executor  executor.Executor
+	Behavior  *dispatch.Behavior
 
 	BuildQueue *Queue[*message.BuildRequest]
+	CraftQueue *Queue[*message.CraftRequest]
 }
 
 func InventoryActorFactory(ctx context.Context) model.Actor {
-	id := ctx.Value(model.KeyID).(uuid.UUID)
+	return NewInventoryActor(ctx)
+}
+
+// NewInventoryActor creates an inventory with the address, clock and executor
+// found in ctx and restores the state it saved before it was last destroyed.
+// Without an actor context in ctx it gets a random ID and saves nothing.
+func NewInventoryActor(ctx context.Context) *InventoryActor {
+	id := uuid.New()
+	actx, ok := model.ActorContextFromContext(ctx)
+	if ok {
+		id = actx.Self().ID
+	}
+
+	buildings := NewCollection[Building]()
+	buildings.Index(IndexName, func(b Building) string { return b.name })
+
+	resources := NewCollection[Resource]()
+	resources.Index(IndexName, func(r Resource) string { return r.name })
 
 	actor := &InventoryActor{
 		ID: id,
 
-		timers: make(map[uuid.UUID]time.Timer),
+		actx:         actx,
+		mx:           &sync.Mutex{},
+		spedUp:       make(map[string]time.Duration),
+		costs:        make(map[string]map[string]uint64),
+		queuedCrafts: make(map[string]Recipe),
 
-		Buildings: NewCollection[Building](),
-		Resources: NewCollection[Resource](),
+		Buildings: buildings,
+		Resources: resources,
+		Modifiers: modifier.Default,
+		Recipes:   DefaultRecipes,
+		Clock:     clock.FromContext(ctx),
+		Executor:  executor.FromContext(ctx),
 
 		BuildQueue: NewQueue[*message.BuildRequest](),
+		CraftQueue: NewQueue[*message.CraftRequest](),
 	}
+	actor.Behavior = dispatch.NewBehavior(inventoryHandlers.Bind(actor))
 
-	go func() {
-		for {
-			if actor.BuildQueue.len() == 0 {
-				continue
-			}
-
-			task := actor.BuildQueue.Unshift()
-
-			slog.Warn("task done", "name", task.Name)
-		}
-	}()
+	if ok {
+		actor.restore()
+	}
 
 	return actor
 }
@@ -154,38 +194,531 @@ func (a *InventoryActor) GetID() uuid.UUID {
 }
 
 func (a *InventoryActor) GetKind() string {
-	return "inventory"
+	return actorpb.InventoryKind
+}
+
+// QueueLengths returns the number of builds and crafts waiting to start.
+func (a *InventoryActor) QueueLengths() map[string]int {
+	return map[string]int{
+		QueueBuild: a.BuildQueue.Len(),
+		QueueCraft: a.CraftQueue.Len(),
+	}
+}
+
+// InventoryState is the state of an inventory as returned by Inspect.
+type InventoryState struct {
+	Buildings  []BuildingState `json:"buildings"`
+	Resources  []ResourceState `json:"resources"`
+	Current    *BuildState     `json:"current,omitempty"`
+	BuildQueue []BuildState    `json:"build_queue"`
+	Crafting   *CraftState     `json:"crafting,omitempty"`
+	CraftQueue []CraftState    `json:"craft_queue"`
+}
+
+type BuildingState struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+type ResourceState struct {
+	ID     string `json:"id"`
+	Name   string `json:"name"`
+	Amount uint   `json:"amount"`
+}
+
+type BuildState struct {
+	ID        string      `json:"id"`
+	Name      string      `json:"name"`
+	Duration  string      `json:"duration"`
+	Status    TimerStatus `json:"status,omitempty"`
+	Remaining string      `json:"remaining,omitempty"`
+}
+
+type CraftState struct {
+	ID        string      `json:"id"`
+	Recipe    string      `json:"recipe"`
+	Status    TimerStatus `json:"status,omitempty"`
+	Remaining string      `json:"remaining,omitempty"`
+}
+
+func buildState(req *message.BuildRequest) BuildState {
+	return BuildState{
+		ID:       req.ID,
+		Name:     req.Name,
+		Duration: req.Duration,
+	}
 }
 
+// Inspect returns the buildings, resources and queues of the inventory, with
+// buildings and resources sorted by name.
+func (a *InventoryActor) Inspect() any {
+	state := InventoryState{
+		Buildings:  make([]BuildingState, 0),
+		Resources:  make([]ResourceState, 0),
+		BuildQueue: make([]BuildState, 0),
+		CraftQueue: make([]CraftState, 0),
+	}
+
+	for _, building := range a.Buildings.All() {
+		state.Buildings = append(state.Buildings, BuildingState{ID: building.id.String(), Name: building.name})
+	}
+	slices.SortFunc(state.Buildings, func(x, y BuildingState) int {
+		return cmp.Or(strings.Compare(x.Name, y.Name), strings.Compare(x.ID, y.ID))
+	})
+
+	for _, resource := range a.Resources.All() {
+		state.Resources = append(state.Resources, ResourceState{ID: resource.id.String(), Name: resource.name, Amount: resource.amount})
+	}
+	slices.SortFunc(state.Resources, func(x, y ResourceState) int {
+		return cmp.Or(strings.Compare(x.Name, y.Name), strings.Compare(x.ID, y.ID))
+	})
+
+	a.mx.Lock()
+	if a.current != nil {
+		current := buildState(a.current.request)
+		status := a.current.status(a.Clock.Now())
+		current.Status = status.Status
+		current.Remaining = status.Remaining.String()
+		state.Current = &current
+	}
+
+	for _, req := range a.BuildQueue.Items() {
+		queued := buildState(req)
+		if remaining, ok := a.spedUp[req.ID]; ok {
+			queued.Remaining = remaining.String()
+		}
+		state.BuildQueue = append(state.BuildQueue, queued)
+	}
+
+	if a.crafting != nil {
+		status := a.crafting.status(a.Clock.Now())
+		state.Crafting = &CraftState{
+			ID:        a.crafting.request.ID,
+			Recipe:    a.crafting.request.Recipe,
+			Status:    status.Status,
+			Remaining: status.Remaining.String(),
+		}
+	}
+	a.mx.Unlock()
+
+	for _, req := range a.CraftQueue.Items() {
+		state.CraftQueue = append(state.CraftQueue, CraftState{ID: req.ID, Recipe: req.Recipe})
+	}
+
+	return state
+}
+
+// Receive hands msg to the current behavior of the inventory, its handlers
+// unless it has switched to another state.
 func (a *InventoryActor) Receive(ctx context.Context, msg proto.Message, res proto.Message) error {
-	req, ok := msg.(*message.BuildRequest)
-	if !ok {
-		return fmt.Errorf("invalid message type")
+	return a.Behavior.Receive(ctx, msg, res)
+}
+
+func (a *InventoryActor) Build(ctx context.Context, req *message.BuildRequest, res *message.BuildResponse) error {
+	if req.ID == "" {
+		req.ID = uuid.New().String()
+	} else if _, err := uuid.Parse(req.ID); err != nil {
+		return fmt.Errorf("invalid build id: %w", err)
 	}
 
-	slog.Info("actor received message",
-		"actor_kind", a.GetKind(),
-		"actor_id", a.GetID(),
-		"building_name", req.Name,
-	)
+	now := a.Clock.Now()
+	if _, _, err := a.buildDuration(req, now); err != nil {
+		return err
+	}
+	cost, _ := a.buildCost(req, now)
 
-	newLen := a.BuildQueue.Push(req)
+	a.mx.Lock()
+	err := a.debit(cost)
+	if err == nil {
+		a.costs[req.ID] = cost
+	}
+	a.mx.Unlock()
+	if err != nil {
+		return err
+	}
+
+	newLen, err := a.BuildQueue.Push(req)
+	if err != nil {
+		a.mx.Lock()
+		delete(a.costs, req.ID)
+		a.credit(cost)
+		a.mx.Unlock()
+
+		return err
+	}
+
+	a.mx.Lock()
+	a.save()
+	a.mx.Unlock()
 
 	slog.Info("added request to build queue",
+		"id", req.ID,
 		"name", req.Name,
 		"duration", req.Duration,
 		"len", newLen,
 	)
 
+	a.Executor.Go(a.nextBuild)
+
+	reply(res, req.TraceID, req.ID)
+
+	return nil
+}
+
+func (a *InventoryActor) SpeedUp(ctx context.Context, req *message.SpeedUpRequest, res *message.BuildResponse) error {
+	remaining, err := a.speedUp(req)
+	if err != nil {
+		return err
+	}
+
+	reply(res, req.TraceID, remaining.String())
+
 	return nil
 }
 
+// nextBuild starts the timer of the next queued build unless a build is
+// already in progress. The timer calls finishBuild once it is done.
+func (a *InventoryActor) nextBuild() {
+	a.mx.Lock()
+	defer a.mx.Unlock()
+	defer a.save()
+
+	for a.current == nil && !a.stopped {
+		req := a.BuildQueue.Unshift()
+		if req == nil {
+			return
+		}
+
+		duration, err := a.queuedDuration(req)
+		cost := a.costs[req.ID]
+		delete(a.spedUp, req.ID)
+		delete(a.costs, req.ID)
+		if err != nil {
+			slog.Error("invalid build duration", "id", req.ID, "duration", req.Duration, "error", err)
+			a.credit(cost)
+			continue
+		}
+
+		a.current = &activeBuild{request: req}
+		a.startBuild(duration)
+	}
+}
+
+// startBuild starts the timer of the build in progress, which finishes after
+// d. The caller must hold a.mx.
+func (a *InventoryActor) startBuild(d time.Duration) {
+	a.current.timer = NewTimerActorFunc(a.Clock, a.Executor, uuid.MustParse(a.current.request.ID), d, a.finishBuild)
+	a.adopt(a.current.timer)
+	a.wake(&a.current.activeTimer, d, &message.BuildTimerFired{BuildID: a.current.request.ID})
+}
+
+// queuedDuration returns how long a queued build takes once started: its
+// duration with the modifiers active now, unless it was sped up while queued.
+// The caller must hold a.mx.
+func (a *InventoryActor) queuedDuration(req *message.BuildRequest) (time.Duration, error) {
+	if duration, ok := a.spedUp[req.ID]; ok {
+		return duration, nil
+	}
+
+	duration, effects, err := a.buildDuration(req, a.Clock.Now())
+	if err != nil {
+		return 0, err
+	}
+	for _, effect := range effects {
+		slog.Info("modifier applied to build", append(effect.Modifier.Attributes(), "id", req.ID)...)
+	}
+
+	return duration, nil
+}
+
+// adopt makes the timer of a build or craft a child of the inventory, so that it stops
+// with the inventory. The caller must hold a.mx.
+func (a *InventoryActor) adopt(timer *TimerActor) {
+	if a.actx == nil {
+		return
+	}
+
+	if _, err := a.actx.SpawnActor(timer); err != nil {
+		slog.Error("failed to adopt timer", append(timer.Attributes(), "error", err)...)
+	}
+}
+
+// wake replaces the durable timer of active with one delivering msg after d,
+// when active finishes. The caller must hold a.mx.
+func (a *InventoryActor) wake(active *activeTimer, d time.Duration, msg proto.Message) {
+	active.fireAt = a.Clock.Now().Add(d)
+	if a.actx == nil {
+		return
+	}
+
+	a.unwake(active)
+
+	id, err := a.actx.After(d, msg)
+	if err != nil {
+		slog.Error("failed to set wake-up timer", "id", a.ID, "error", err)
+		return
+	}
+	active.wake = id
+}
+
+// unwake cancels the durable timer of active. The caller must hold a.mx.
+func (a *InventoryActor) unwake(active *activeTimer) {
+	if a.actx == nil || active.wake == uuid.Nil {
+		return
+	}
+
+	if err := a.actx.CancelTimer(active.wake); err != nil {
+		slog.Error("failed to cancel wake-up timer", "id", a.ID, "error", err)
+	}
+	active.wake = uuid.Nil
+}
+
+// release stops the child of a finished build or craft timer.
+func (a *InventoryActor) release(timerReply TimerReply) {
+	if a.actx == nil {
+		return
+	}
+
+	address := model.Address{Kind: TimerKind, ID: timerReply.ID}
+	if err := a.actx.Stop(context.Background(), address); err != nil && !errors.Is(err, model.ErrNotActive) {
+		slog.Error("failed to stop timer", append(timerReply.Attributes(), "error", err)...)
+	}
+}
+
+// ChildTerminated is told by the manager when a build or craft timer has
+// stopped.
+func (a *InventoryActor) ChildTerminated(ctx context.Context, req *message.Terminated, res *emptypb.Empty) error {
+	slog.Debug("child actor terminated",
+		"actor_kind", a.GetKind(),
+		"actor_id", a.GetID(),
+		"child_kind", req.GetActor().GetKind(),
+		"child_id", req.GetActor().GetID(),
+		"reason", req.Reason,
+	)
+
+	return nil
+}
+
+// BuildTimerFired is delivered once the build in progress is due. It only
+// activates a passivated inventory, whose restored build timer finishes the
+// build.
+func (a *InventoryActor) BuildTimerFired(ctx context.Context, req *message.BuildTimerFired, res *emptypb.Empty) error {
+	slog.Debug("build wake-up timer fired", "id", a.ID, "build_id", req.BuildID)
+
+	return nil
+}
+
+// finishBuild completes the build in progress once its timer is done. Build
+// timers are only cancelled when the inventory stops, which keeps the build
+// in its saved state.
+func (a *InventoryActor) finishBuild(timerReply TimerReply) {
+	a.release(timerReply)
+	if timerReply.Status != StatusDone {
+		return
+	}
+
+	a.mx.Lock()
+
+	if a.stopped || a.current == nil || a.current.timer == nil || a.current.timer.ID != timerReply.ID {
+		a.mx.Unlock()
+		return
+	}
+
+	a.unwake(&a.current.activeTimer)
+	a.complete(a.current.request)
+	a.current = nil
+
+	a.mx.Unlock()
+
+	a.nextBuild()
+}
+
+func (a *InventoryActor) complete(req *message.BuildRequest) {
+	building := Building{
+		id:   uuid.New(),
+		name: req.Name,
+	}
+	a.Buildings.Set(building)
+
+	slog.Info("build completed", "id", req.ID, "name", req.Name)
+
+	if a.actx != nil {
+		a.actx.Publish(context.Background(), TopicInventory, &message.BuildingCompleted{
+			BuildID:     req.ID,
+			Name:        req.Name,
+			Inventory:   a.actx.Self().Message(),
+			CompletedAt: timestamppb.New(a.Clock.Now()),
+		})
+	}
+}
+
+// speedUp reduces the remaining time of the in-progress or queued build
+// identified by req.BuildID and consumes one req.Item from the resources. It
+// returns the remaining time of the build after the reduction.
+func (a *InventoryActor) speedUp(req *message.SpeedUpRequest) (time.Duration, error) {
+	var reduction time.Duration
+	if req.Duration != "" {
+		var err error
+		if reduction, err = time.ParseDuration(req.Duration); err != nil {
+			return 0, fmt.Errorf("invalid speed-up duration: %w", err)
+		}
+	}
+	if reduction < 0 {
+		return 0, fmt.Errorf("invalid speed-up duration %s", req.Duration)
+	}
+	if req.Percent > 100 {
+		return 0, fmt.Errorf("invalid speed-up percent %d", req.Percent)
+	}
+	if reduction == 0 && req.Percent == 0 {
+		return 0, fmt.Errorf("speed-up requires a duration or a percent")
+	}
+
+	a.mx.Lock()
+	defer a.mx.Unlock()
+
+	if item, ok := a.Resources.First(IndexName, req.Item); !ok || item.amount == 0 {
+		return 0, fmt.Errorf("no %s item available", req.Item)
+	}
+
+	remaining, err := a.reduceBuild(req.BuildID, reduction, req.Percent)
+	if err != nil {
+		return 0, err
+	}
+
+	if err := a.debit(map[string]uint64{req.Item: 1}); err != nil {
+		return 0, err
+	}
+
+	slog.Info("build sped up",
+		"id", req.BuildID,
+		"item", req.Item,
+		"remaining", remaining.String(),
+	)
+
+	a.save()
+
+	return remaining, nil
+}
+
+func (a *InventoryActor) reduceBuild(id string, reduction time.Duration, percent uint32) (time.Duration, error) {
+	if a.current != nil && a.current.request.ID == id {
+		status := a.current.status(a.Clock.Now())
+		if status.Status.Final() {
+			return 0, fmt.Errorf("build %s is already finished", id)
+		}
+
+		remaining := reduce(status.Remaining, reduction, percent)
+		if a.current.timer != nil && !a.current.timer.Reschedule(remaining) {
+			return 0, fmt.Errorf("build %s is already finished", id)
+		}
+		a.wake(&a.current.activeTimer, remaining, &message.BuildTimerFired{BuildID: id})
+
+		return remaining, nil
+	}
+
+	byID := func(r *message.BuildRequest) bool { return r.ID == id }
+
+	queued, ok := a.BuildQueue.Find(byID)
+	if !ok {
+		return 0, fmt.Errorf("build %s not found", id)
+	}
+
+	duration, err := a.queuedDuration(queued)
+	if err != nil {
+		return 0, err
+	}
+
+	remaining := reduce(duration, reduction, percent)
+	if remaining > 0 {
+		a.spedUp[id] = remaining
+		return remaining, nil
+	}
+
+	delete(a.spedUp, id)
+	if _, ok := a.BuildQueue.Remove(byID); ok {
+		delete(a.costs, id)
+		a.complete(queued)
+	}
+
+	return 0, nil
+}
+
+// debit takes the given amounts out of the resources. Nothing is taken unless
+// every amount is available.
+func (a *InventoryActor) debit(amounts map[string]uint64) error {
+	debited := make([]Resource, 0, len(amounts))
+
+	for name, amount := range amounts {
+		if amount == 0 {
+			continue
+		}
+
+		resource, ok := a.Resources.First(IndexName, name)
+		if !ok || uint64(resource.amount) < amount {
+			return fmt.Errorf("not enough %s: %d required", name, amount)
+		}
+
+		resource.amount -= uint(amount)
+		debited = append(debited, resource)
+	}
+
+	for _, resource := range debited {
+		a.Resources.Set(resource)
+	}
+
+	return nil
+}
+
+// credit adds the given amounts to the resources, creating any resource the
+// inventory does not hold yet.
+func (a *InventoryActor) credit(amounts map[string]uint64) {
+	for name, amount := range amounts {
+		resource, ok := a.Resources.First(IndexName, name)
+		if !ok {
+			resource = Resource{id: uuid.New(), name: name}
+		}
+
+		resource.amount += uint(amount)
+		a.Resources.Set(resource)
+	}
+}
+
+func reduce(remaining time.Duration, reduction time.Duration, percent uint32) time.Duration {
+	remaining -= reduction
+	remaining -= remaining * time.Duration(percent) / 100
+
+	return max(remaining, 0)
+}
+
+func reply(res *message.BuildResponse, traceID string, response string) {
+	if res == nil {
+		return
+	}
+
+	res.TraceID = traceID
+	res.Timestamp = timestamppb.Now()
+	res.Response = response
+}
+
 func (a *InventoryActor) Start(ctx context.Context) {
 	slog.Info("starting actor", "kind", "inventory", "id", a.ID.String())
 }
 
 func (a *InventoryActor) Destroy(ctx context.Context) {
 	slog.Info("stopping actor", "kind", "inventory", "id", a.ID.String())
+
+	a.mx.Lock()
+	a.stopped = true
+	if a.current != nil && a.current.timer != nil {
+		a.current.timer.Stop()
+	}
+	if a.crafting != nil && a.crafting.timer != nil {
+		a.crafting.timer.Stop()
+	}
+	a.mx.Unlock()
+
+	a.BuildQueue.Close()
+	a.CraftQueue.Close()
 }
 
 type BuildResponse struct {
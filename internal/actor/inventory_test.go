@@ -1,68 +1,714 @@
 package actor
 
 import (
+	"context"
+	"io"
+	"log/slog"
+	"runtime"
+	"runtime/metrics"
 	"testing"
+	"time"
 
+	"github.com/gnarloqgames/ga-actor-poc/internal/dispatch"
+	"github.com/gnarloqgames/ga-actor-poc/internal/manager"
+	"github.com/gnarloqgames/ga-actor-poc/internal/model"
+	"github.com/gnarloqgames/ga-actor-poc/internal/modifier"
+	"github.com/gnarloqgames/ga-actor-poc/internal/sim"
+	"github.com/gnarloqgames/ga-actor-poc/internal/testkit"
 	"github.com/gnarloqgames/ga-actor-poc/message"
+	"github.com/gnarloqgames/ga-actor-poc/message/actorpb"
+	"github.com/google/uuid"
 	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/structpb"
 )
 
-func TestQueueUnshift(t *testing.T) {
+func addTestResource(a *InventoryActor, name string, amount uint) uuid.UUID {
+	resource := Resource{id: uuid.New(), name: name, amount: amount}
+	a.Resources.Set(resource)
+
+	return resource.id
+}
+
+var halfDuration = modifier.Modifier{
+	Scope:     modifier.ScopeGlobal,
+	Target:    modifier.TargetBuildDuration,
+	Operation: modifier.OperationMultiply,
+	Value:     0.5,
+}
+
+func TestSpeedUp(t *testing.T) {
 	tests := []struct {
-		label         string
-		queue         []*message.BuildRequest
-		expectedValue *message.BuildRequest
-		expectedQueue []*message.BuildRequest
+		label             string
+		queue             []string
+		modifiers         []modifier.Modifier
+		target            int
+		elapsed           time.Duration
+		duration          string
+		percent           uint32
+		expectedRemaining time.Duration
+		expectedBuildings int
 	}{
 		{
-			label:         "empty",
-			queue:         make([]*message.BuildRequest, 0),
-			expectedValue: nil,
-			expectedQueue: make([]*message.BuildRequest, 0),
+			label:             "in progress duration",
+			queue:             []string{"1h"},
+			target:            0,
+			duration:          "59m59s",
+			expectedRemaining: time.Second,
+			expectedBuildings: 0,
 		},
 		{
-			label: "one",
-			queue: []*message.BuildRequest{
-				{Name: "test_1"},
-			},
-			expectedValue: &message.BuildRequest{Name: "test_1"},
-			expectedQueue: make([]*message.BuildRequest, 0),
+			label:             "in progress to zero",
+			queue:             []string{"1h"},
+			target:            0,
+			duration:          "2h",
+			expectedRemaining: 0,
+			expectedBuildings: 1,
 		},
 		{
-			label: "more",
-			queue: []*message.BuildRequest{
-				{Name: "test_1"},
-				{Name: "test_2"},
-			},
-			expectedValue: &message.BuildRequest{Name: "test_1"},
-			expectedQueue: []*message.BuildRequest{
-				{Name: "test_2"},
-			},
+			label:             "multi-hour build",
+			queue:             []string{"36h"},
+			target:            0,
+			elapsed:           30 * time.Hour,
+			duration:          "5h",
+			expectedRemaining: time.Hour,
+			expectedBuildings: 0,
+		},
+		{
+			label:             "queued percent",
+			queue:             []string{"1h", "1h"},
+			target:            1,
+			percent:           25,
+			expectedRemaining: 45 * time.Minute,
+			expectedBuildings: 0,
+		},
+		{
+			label:             "queued to zero",
+			queue:             []string{"1h", "1h"},
+			target:            1,
+			percent:           100,
+			expectedRemaining: 0,
+			expectedBuildings: 1,
+		},
+		{
+			label:             "in progress modified",
+			queue:             []string{"1h"},
+			modifiers:         []modifier.Modifier{halfDuration},
+			target:            0,
+			percent:           25,
+			expectedRemaining: 22*time.Minute + 30*time.Second,
+			expectedBuildings: 0,
+		},
+		{
+			label:             "queued modified",
+			queue:             []string{"1h", "1h"},
+			modifiers:         []modifier.Modifier{halfDuration},
+			target:            1,
+			percent:           25,
+			expectedRemaining: 22*time.Minute + 30*time.Second,
+			expectedBuildings: 0,
+		},
+		{
+			label:             "queued modified to zero",
+			queue:             []string{"1h", "1h"},
+			modifiers:         []modifier.Modifier{halfDuration},
+			target:            1,
+			duration:          "30m",
+			expectedRemaining: 0,
+			expectedBuildings: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		tf := func(t *testing.T) {
+			a, fake := testkit.Spawn[*InventoryActor](t, actorpb.InventoryKind, InventoryActorFactory)
+			a.Modifiers = modifier.NewRegistry()
+			for _, m := range tt.modifiers {
+				a.Modifiers.Add(m)
+			}
+			itemID := addTestResource(a, "speedup", 2)
+
+			ids := make([]string, 0, len(tt.queue))
+			for _, duration := range tt.queue {
+				res := &message.BuildResponse{}
+				err := a.Receive(context.Background(), &message.BuildRequest{Name: "farm", Duration: duration}, res)
+				require.NoError(t, err)
+				ids = append(ids, res.Response)
+			}
+			testkit.AwaitTimers(t, fake, 1)
+			fake.Advance(tt.elapsed)
+
+			res := &message.BuildResponse{}
+			err := a.Receive(context.Background(), &message.SpeedUpRequest{
+				BuildID:  ids[tt.target],
+				Item:     "speedup",
+				Duration: tt.duration,
+				Percent:  tt.percent,
+			}, res)
+			require.NoError(t, err)
+
+			remaining, err := time.ParseDuration(res.Response)
+			require.NoError(t, err)
+			require.Equal(t, tt.expectedRemaining, remaining)
+
+			fake.Advance(0)
+
+			require.Eventually(t, func() bool {
+				return a.Buildings.Len() == tt.expectedBuildings
+			}, time.Second, time.Millisecond)
+
+			item, ok := a.Resources.Get(itemID)
+			require.True(t, ok)
+			require.Equal(t, uint(1), item.amount)
+		}
+
+		t.Run(tt.label, tf)
+	}
+}
+
+func TestSpeedUpQueuedModified(t *testing.T) {
+	a, fake := testkit.Spawn[*InventoryActor](t, actorpb.InventoryKind, InventoryActorFactory)
+	a.Modifiers = modifier.NewRegistry()
+	a.Modifiers.Add(halfDuration)
+	addTestResource(a, "speedup", 1)
+
+	ids := make([]string, 0, 2)
+	for range 2 {
+		res := &message.BuildResponse{}
+		err := a.Receive(context.Background(), &message.BuildRequest{Name: "farm", Duration: "1h"}, res)
+		require.NoError(t, err)
+		ids = append(ids, res.Response)
+	}
+	testkit.AwaitTimers(t, fake, 1)
+
+	err := a.Receive(context.Background(), &message.SpeedUpRequest{BuildID: ids[1], Item: "speedup", Percent: 25}, &message.BuildResponse{})
+	require.NoError(t, err)
+	require.Equal(t, "22m30s", a.Inspect().(InventoryState).BuildQueue[0].Remaining)
+
+	fake.Advance(30 * time.Minute)
+	require.Eventually(t, func() bool {
+		state := a.Inspect().(InventoryState)
+		return state.Current != nil && state.Current.ID == ids[1]
+	}, time.Second, time.Millisecond)
+	require.Equal(t, "22m30s", a.Inspect().(InventoryState).Current.Remaining)
+
+	fake.Advance(22*time.Minute + 30*time.Second)
+	require.Eventually(t, func() bool {
+		return a.Buildings.Len() == 2
+	}, time.Second, time.Millisecond)
+}
+
+func TestSpeedUpErrors(t *testing.T) {
+	a, _ := testkit.Spawn[*InventoryActor](t, actorpb.InventoryKind, InventoryActorFactory)
+	addTestResource(a, "speedup", 1)
+	addTestResource(a, "empty", 0)
+
+	res := &message.BuildResponse{}
+	err := a.Receive(context.Background(), &message.BuildRequest{Name: "farm", Duration: "1h"}, res)
+	require.NoError(t, err)
+
+	tests := []struct {
+		label   string
+		request *message.SpeedUpRequest
+	}{
+		{
+			label:   "unknown build",
+			request: &message.SpeedUpRequest{BuildID: uuid.New().String(), Item: "speedup", Duration: "1m"},
+		},
+		{
+			label:   "missing item",
+			request: &message.SpeedUpRequest{BuildID: res.Response, Item: "missing", Duration: "1m"},
+		},
+		{
+			label:   "depleted item",
+			request: &message.SpeedUpRequest{BuildID: res.Response, Item: "empty", Duration: "1m"},
+		},
+		{
+			label:   "no reduction",
+			request: &message.SpeedUpRequest{BuildID: res.Response, Item: "speedup"},
+		},
+		{
+			label:   "invalid percent",
+			request: &message.SpeedUpRequest{BuildID: res.Response, Item: "speedup", Percent: 101},
+		},
+		{
+			label:   "negative duration",
+			request: &message.SpeedUpRequest{BuildID: res.Response, Item: "speedup", Duration: "-5h", Percent: 1},
 		},
 	}
 
 	for _, tt := range tests {
-		queue := NewQueue[*message.BuildRequest]()
-		for _, item := range tt.queue {
-			queue.Push(item) //nolint
+		tf := func(t *testing.T) {
+			err := a.Receive(context.Background(), tt.request, nil)
+			require.Error(t, err)
 		}
 
+		t.Run(tt.label, tf)
+	}
+}
+
+func TestBuildCost(t *testing.T) {
+	tests := []struct {
+		label         string
+		wood          uint
+		duration      string
+		modifiers     []modifier.Modifier
+		expectedError bool
+		expectedWood  uint
+	}{
+		{
+			label:         "enough",
+			wood:          100,
+			duration:      "1h",
+			expectedError: false,
+			expectedWood:  0,
+		},
+		{
+			label:         "not enough",
+			wood:          99,
+			duration:      "1h",
+			expectedError: true,
+			expectedWood:  99,
+		},
+		{
+			label:    "discounted",
+			wood:     99,
+			duration: "1h",
+			modifiers: []modifier.Modifier{
+				{Scope: modifier.ScopeGlobal, Target: modifier.TargetBuildCost, Resource: "wood", Operation: modifier.OperationMultiply, Value: 0.5},
+			},
+			expectedError: false,
+			expectedWood:  49,
+		},
+		{
+			label:         "invalid duration",
+			wood:          100,
+			duration:      "bogus",
+			expectedError: true,
+			expectedWood:  100,
+		},
+	}
+
+	for _, tt := range tests {
 		tf := func(t *testing.T) {
-			val := queue.Unshift()
+			a, _ := testkit.Spawn[*InventoryActor](t, actorpb.InventoryKind, InventoryActorFactory)
+			a.Modifiers = modifier.NewRegistry()
+			for _, m := range tt.modifiers {
+				a.Modifiers.Add(m)
+			}
+			woodID := addTestResource(a, "wood", tt.wood)
 
-			if tt.expectedValue == nil {
-				require.Nil(t, val)
+			err := a.Receive(context.Background(), &message.BuildRequest{
+				Name:     "farm",
+				Duration: tt.duration,
+				Cost:     map[string]uint64{"wood": 100},
+			}, nil)
+			if tt.expectedError {
+				require.Error(t, err)
 			} else {
-				require.Equal(t, tt.expectedValue.Name, val.Name)
+				require.NoError(t, err)
 			}
 
-			actualItems := make([]*message.BuildRequest, 0)
-			for _, item := range queue.items {
-				actualItems = append(actualItems, item)
-			}
-			require.ElementsMatch(t, tt.expectedQueue, actualItems)
+			wood, ok := a.Resources.Get(woodID)
+			require.True(t, ok)
+			require.Equal(t, tt.expectedWood, wood.amount)
 		}
 
 		t.Run(tt.label, tf)
 	}
 }
+
+func TestModifierQuery(t *testing.T) {
+	a, _ := testkit.Spawn[*InventoryActor](t, actorpb.InventoryKind, InventoryActorFactory)
+	a.Modifiers = modifier.NewRegistry()
+
+	player := uuid.New()
+	eventID := a.Modifiers.Add(modifier.Modifier{
+		Name:      "weekend event",
+		Scope:     modifier.ScopeGlobal,
+		Target:    modifier.TargetBuildDuration,
+		Operation: modifier.OperationMultiply,
+		Value:     0.5,
+		End:       time.Now().Add(time.Hour),
+	})
+	a.Modifiers.Add(modifier.Modifier{
+		Scope:     modifier.ScopePlayer,
+		Subject:   player,
+		Target:    modifier.TargetBuildCost,
+		Operation: modifier.OperationAdd,
+		Value:     -10,
+	})
+	a.Modifiers.Add(modifier.Modifier{
+		Scope:     modifier.ScopeInventory,
+		Subject:   uuid.New(),
+		Target:    modifier.TargetBuildDuration,
+		Operation: modifier.OperationMultiply,
+		Value:     0.5,
+	})
+
+	buildContext, err := structpb.NewStruct(map[string]any{ContextPlayerID: player.String()})
+	require.NoError(t, err)
+
+	res := &message.ModifierResponse{}
+	err = a.Receive(context.Background(), &message.ModifierQuery{
+		Build: &message.BuildRequest{
+			Name:     "farm",
+			Duration: "1h",
+			Cost:     map[string]uint64{"wood": 100},
+			Context:  buildContext,
+		},
+	}, res)
+	require.NoError(t, err)
+
+	require.Len(t, res.Active, 2)
+	require.Equal(t, "30m0s", res.Duration)
+	require.Equal(t, map[string]uint64{"wood": 90}, res.Cost)
+	require.Len(t, res.Effects, 2)
+	require.Equal(t, eventID.String(), res.Effects[0].ModifierID)
+	require.Equal(t, float64(time.Hour), res.Effects[0].Before)
+	require.Equal(t, float64(30*time.Minute), res.Effects[0].After)
+}
+
+func TestDestroyClosesQueues(t *testing.T) {
+	a, _ := testkit.Spawn[*InventoryActor](t, actorpb.InventoryKind, InventoryActorFactory)
+	a.Destroy(context.Background())
+
+	err := a.Receive(context.Background(), &message.BuildRequest{Name: "farm", Duration: "1h"}, nil)
+	require.ErrorIs(t, err, ErrQueueClosed)
+}
+
+func TestReceiveUnhandled(t *testing.T) {
+	a, _ := testkit.Spawn[*InventoryActor](t, actorpb.InventoryKind, InventoryActorFactory)
+
+	err := a.Receive(context.Background(), &message.BuildResponse{}, nil)
+	require.ErrorIs(t, err, dispatch.ErrUnhandled)
+
+	err = a.Receive(context.Background(), &message.ModifierQuery{}, &message.BuildResponse{})
+	require.ErrorIs(t, err, dispatch.ErrInvalidResponse)
+}
+
+func TestInventoryClient(t *testing.T) {
+	kit := testkit.New(t)
+	require.NoError(t, actorpb.RegisterInventory(kit.Manager, NewInventoryActor))
+	client := actorpb.NewInventoryClient(kit.Manager, testkit.DefaultTimeout)
+	address := model.Address{Kind: actorpb.InventoryKind, ID: uuid.New()}
+
+	build, err := client.Build(context.Background(), address, &message.BuildRequest{TraceID: "trace", Name: "farm", Duration: "1h"})
+	require.NoError(t, err)
+	require.Equal(t, "trace", build.TraceID)
+	require.NotEmpty(t, build.Response)
+
+	query, err := client.QueryModifiers(context.Background(), address, &message.ModifierQuery{TraceID: "query"})
+	require.NoError(t, err)
+	require.Equal(t, "query", query.TraceID)
+
+	_, err = client.SpeedUp(context.Background(), address, &message.SpeedUpRequest{BuildID: build.Response, Item: "missing", Duration: "1m"})
+	require.Error(t, err)
+
+	_, err = client.Build(context.Background(), model.Address{Kind: "probe", ID: address.ID}, &message.BuildRequest{Name: "farm", Duration: "1h"})
+	require.Error(t, err)
+}
+
+// simulateInventory sends a random workload, drawn from seed, to a single
+// inventory in a simulation and checks its invariants after every step. It
+// returns a summary of the final state.
+func simulateInventory(t *testing.T, seed uint64) []uint {
+	s := sim.New(seed)
+	require.NoError(t, s.Manager.NewKind("inventory", InventoryActorFactory))
+
+	address := s.NewAddress("inventory")
+	actor, err := s.Manager.Actor(address)
+	require.NoError(t, err)
+	a := actor.(*InventoryActor)
+	t.Cleanup(func() { a.Destroy(context.Background()) })
+
+	a.Recipes = newTestRecipeBook()
+	a.Modifiers = modifier.NewRegistry()
+	a.Buildings.Set(Building{id: uuid.New(), name: "sawmill"})
+	addTestResource(a, "wood", 200)
+	addTestResource(a, "speedup", 5)
+
+	random := s.Rand()
+	builds := make([]*message.BuildRequest, 0)
+	accepted := 0
+
+	for range 50 {
+		switch random.IntN(3) {
+		case 0:
+			req := &message.BuildRequest{
+				Name:     "farm",
+				Duration: (time.Duration(1+random.IntN(180)) * time.Minute).String(),
+				Cost:     map[string]uint64{"wood": random.Uint64N(50)},
+			}
+			builds = append(builds, req)
+			s.Send(address, req, func(err error) {
+				if err == nil {
+					accepted++
+				}
+			})
+		case 1:
+			if len(builds) == 0 {
+				continue
+			}
+			req := builds[random.IntN(len(builds))]
+			s.Send(address, &message.SpeedUpRequest{
+				BuildID: req.ID,
+				Item:    "speedup",
+				Percent: uint32(1 + random.IntN(100)),
+			}, nil)
+		case 2:
+			s.Send(address, &message.CraftRequest{Recipe: "plank"}, nil)
+		}
+
+		for range random.IntN(5) {
+			s.Step()
+			checkInventory(t, a, accepted)
+		}
+	}
+
+	for s.Step() {
+		checkInventory(t, a, accepted)
+	}
+
+	farms := len(a.Buildings.Lookup(IndexName, "farm"))
+	require.Equal(t, accepted, farms, "every accepted build completes exactly once")
+
+	return []uint{uint(farms), resourceAmount(a, "wood"), resourceAmount(a, "plank"), resourceAmount(a, "speedup")}
+}
+
+func checkInventory(t *testing.T, a *InventoryActor, accepted int) {
+	t.Helper()
+
+	a.mx.Lock()
+	defer a.mx.Unlock()
+
+	require.LessOrEqual(t, resourceAmount(a, "wood"), uint(200), "wood went negative")
+	require.LessOrEqual(t, resourceAmount(a, "speedup"), uint(5), "speedup went negative")
+
+	inProgress := a.BuildQueue.Len() + len(a.Buildings.Lookup(IndexName, "farm"))
+	if a.current != nil {
+		inProgress++
+	}
+	require.LessOrEqual(t, inProgress, accepted, "more builds than accepted")
+}
+
+func FuzzInventory(f *testing.F) {
+	logger := slog.Default()
+	f.Cleanup(func() { slog.SetDefault(logger) })
+	slog.SetDefault(slog.New(slog.NewTextHandler(io.Discard, nil)))
+
+	for seed := range uint64(8) {
+		f.Add(seed)
+	}
+
+	f.Fuzz(func(t *testing.T, seed uint64) {
+		simulateInventory(t, seed)
+	})
+}
+
+func TestInventorySimulationReplays(t *testing.T) {
+	logger := slog.Default()
+	t.Cleanup(func() { slog.SetDefault(logger) })
+	slog.SetDefault(slog.New(slog.NewTextHandler(io.Discard, nil)))
+
+	require.Equal(t, simulateInventory(t, 7), simulateInventory(t, 7))
+}
+
+// cpuSeconds returns the CPU time spent running Go code so far. The runtime
+// only updates the metric during garbage collection, so it forces one.
+func cpuSeconds() float64 {
+	runtime.GC()
+
+	sample := []metrics.Sample{{Name: "/cpu/classes/user:cpu-seconds"}}
+	metrics.Read(sample)
+
+	return sample[0].Value.Float64()
+}
+
+// BenchmarkIdleInventories reports the CPU time 10k inventories without
+// queued work consume per millisecond of wall time.
+func BenchmarkIdleInventories(b *testing.B) {
+	// Cleanups run last in first out, so the inventories are destroyed
+	// before logging is restored.
+	logger := slog.Default()
+	b.Cleanup(func() { slog.SetDefault(logger) })
+	slog.SetDefault(slog.New(slog.NewTextHandler(io.Discard, nil)))
+
+	for i := 0; i < 10000; i++ {
+		testkit.Spawn[*InventoryActor](b, actorpb.InventoryKind, InventoryActorFactory)
+	}
+
+	start := cpuSeconds()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		time.Sleep(time.Millisecond)
+	}
+
+	b.StopTimer()
+	b.ReportMetric((cpuSeconds()-start)/float64(b.N)*float64(time.Second), "cpu-ns/op")
+}
+
+func TestBuildTimerIsChild(t *testing.T) {
+	kit := testkit.New(t)
+	kit.Register(actorpb.InventoryKind, InventoryActorFactory)
+	address := model.Address{Kind: actorpb.InventoryKind, ID: uuid.New()}
+
+	kit.Send(address, &message.BuildRequest{Name: "farm", Duration: "1h"})
+	kit.Send(address, &message.BuildRequest{Name: "farm", Duration: "1h"})
+	testkit.AwaitTimers(t, kit.Clock, 1)
+
+	children := kit.Manager.Children(address)
+	require.Len(t, children, 1)
+	require.Equal(t, TimerKind, children[0].Kind)
+
+	kit.Advance(time.Hour)
+	require.Eventually(t, func() bool {
+		next := kit.Manager.Children(address)
+		return len(next) == 1 && next[0] != children[0]
+	}, testkit.DefaultTimeout, time.Millisecond, "the finished timer is replaced by the next one")
+
+	timer := kit.Manager.Children(address)[0]
+	require.NoError(t, kit.Manager.Stop(context.Background(), address))
+	require.Empty(t, kit.Manager.Children(address))
+
+	_, err := kit.Manager.Actor(timer)
+	require.ErrorIs(t, err, model.ErrNotActive)
+}
+
+func TestPauseBuild(t *testing.T) {
+	kit := testkit.New(t)
+	kit.Register(actorpb.InventoryKind, InventoryActorFactory)
+	address := model.Address{Kind: actorpb.InventoryKind, ID: uuid.New()}
+
+	kit.Send(address, &message.BuildRequest{Name: "farm", Duration: "1h"})
+	testkit.AwaitTimers(t, kit.Clock, 1)
+	a := kit.Actor(address).(*InventoryActor)
+
+	children := kit.Manager.Children(address)
+	require.Len(t, children, 1)
+	timer := kit.Actor(children[0]).(*TimerActor)
+
+	kit.Advance(15 * time.Minute)
+	timer.Pause()
+	kit.Advance(time.Hour)
+
+	state := a.Inspect().(InventoryState)
+	require.Equal(t, StatusPaused, state.Current.Status)
+	require.Equal(t, "45m0s", state.Current.Remaining)
+	require.Zero(t, a.Buildings.Len())
+
+	timer.Resume()
+	kit.Advance(45 * time.Minute)
+	require.Eventually(t, func() bool {
+		return a.Buildings.Len() == 1
+	}, testkit.DefaultTimeout, time.Millisecond)
+}
+
+func TestInventoryStashWhileLocked(t *testing.T) {
+	a, _ := testkit.Spawn[*InventoryActor](t, actorpb.InventoryKind, InventoryActorFactory)
+	a.Behavior.BecomeStacked(a.Behavior.Stash)
+
+	err := a.Receive(context.Background(), &message.BuildRequest{Name: "farm", Duration: "1h"}, nil)
+	require.NoError(t, err)
+	require.Equal(t, 1, a.Behavior.StashLen())
+	require.Zero(t, a.BuildQueue.Len())
+
+	require.True(t, a.Behavior.Unbecome())
+	a.Behavior.Unstash()
+	require.Zero(t, a.Behavior.StashLen())
+	require.Eventually(t, func() bool {
+		a.mx.Lock()
+		defer a.mx.Unlock()
+
+		return a.current != nil
+	}, testkit.DefaultTimeout, time.Millisecond)
+}
+
+func TestBuildingCompletedEvent(t *testing.T) {
+	kit := testkit.New(t)
+	kit.Register(actorpb.InventoryKind, InventoryActorFactory)
+	address := model.Address{Kind: actorpb.InventoryKind, ID: uuid.New()}
+
+	events := make(chan manager.Event, 1)
+	kit.Manager.SubscribeChan(manager.Filter{Topic: TopicInventory}, events)
+
+	kit.Send(address, &message.BuildRequest{Name: "farm", Duration: "1h"})
+	testkit.AwaitTimers(t, kit.Clock, 1)
+	kit.Advance(time.Hour)
+
+	select {
+	case event := <-events:
+		completed := event.Message.(*message.BuildingCompleted)
+		require.Equal(t, "farm", completed.Name)
+		require.Equal(t, address.ID.String(), completed.Inventory.GetID())
+		require.Equal(t, testkit.Epoch.Add(time.Hour), completed.CompletedAt.AsTime())
+		require.Equal(t, address, event.Publisher)
+	case <-time.After(testkit.DefaultTimeout):
+		t.Fatal("no event published")
+	}
+}
+
+func TestPassivateBuild(t *testing.T) {
+	kit := testkit.New(t)
+	kit.Register(actorpb.InventoryKind, InventoryActorFactory)
+	address := model.Address{Kind: actorpb.InventoryKind, ID: uuid.New()}
+
+	passivated := kit.Actor(address).(*InventoryActor)
+	addTestResource(passivated, "wood", 10)
+	for _, name := range []string{"farm", "mill"} {
+		kit.Send(address, &message.BuildRequest{Name: name, Duration: "1h", Cost: map[string]uint64{"wood": 4}})
+	}
+	testkit.AwaitTimers(t, kit.Clock, 2)
+	require.NoError(t, kit.Manager.Passivate(context.Background(), address))
+	require.Empty(t, kit.Manager.Children(address))
+
+	kit.Advance(time.Hour)
+	a := kit.Actor(address).(*InventoryActor)
+	require.NotSame(t, passivated, a)
+	require.Eventually(t, func() bool {
+		kit.Advance(0)
+		_, ok := a.Buildings.First(IndexName, "farm")
+		return ok
+	}, testkit.DefaultTimeout, time.Millisecond, "the restored build completes")
+
+	wood, ok := a.Resources.First(IndexName, "wood")
+	require.True(t, ok)
+	require.Equal(t, uint(2), wood.amount)
+
+	require.Eventually(t, func() bool {
+		state := a.Inspect().(InventoryState)
+		return state.Current != nil && state.Current.Name == "mill"
+	}, testkit.DefaultTimeout, time.Millisecond, "the queued build starts")
+	kit.Advance(time.Hour)
+	require.Eventually(t, func() bool {
+		return a.Buildings.Len() == 2
+	}, testkit.DefaultTimeout, time.Millisecond)
+}
+
+func TestRestoreBuildAfterRestart(t *testing.T) {
+	timers, err := manager.NewFileTimerStore(t.TempDir())
+	require.NoError(t, err)
+	states, err := manager.NewFileStateStore(t.TempDir())
+	require.NoError(t, err)
+
+	start := func() *testkit.Kit {
+		kit := testkit.New(t)
+		require.NoError(t, kit.Manager.UseTimerStore(timers))
+		kit.Manager.UseStateStore(states)
+		kit.Register(actorpb.InventoryKind, InventoryActorFactory)
+
+		return kit
+	}
+	address := model.Address{Kind: actorpb.InventoryKind, ID: uuid.New()}
+
+	before := start()
+	before.Send(address, &message.BuildRequest{Name: "farm", Duration: "1h"})
+	testkit.AwaitTimers(t, before.Clock, 2)
+
+	after := start()
+	after.Advance(time.Hour)
+	require.Eventually(t, func() bool {
+		after.Advance(0)
+		return after.Actor(address).(*InventoryActor).Buildings.Len() == 1
+	}, testkit.DefaultTimeout, time.Millisecond, "the build completes after the restart")
+}
@@ -0,0 +1,117 @@
+package actor
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/gnarloqgames/ga-actor-poc/internal/modifier"
+	"github.com/gnarloqgames/ga-actor-poc/message"
+	"github.com/google/uuid"
+	"google.golang.org/protobuf/types/known/structpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const ContextPlayerID string = "player_id"
+
+// subjects identifies the inventory and, if the request context names one, the
+// player a value is computed for.
+func (a *InventoryActor) subjects(requestContext *structpb.Struct) modifier.Subjects {
+	subjects := modifier.Subjects{Inventory: a.ID}
+
+	if value, ok := requestContext.GetFields()[ContextPlayerID]; ok {
+		if player, err := uuid.Parse(value.GetStringValue()); err == nil {
+			subjects.Player = player
+		}
+	}
+
+	return subjects
+}
+
+func (a *InventoryActor) buildDuration(req *message.BuildRequest, at time.Time) (time.Duration, []modifier.Effect, error) {
+	base, err := time.ParseDuration(req.Duration)
+	if err != nil {
+		return 0, nil, fmt.Errorf("invalid build duration: %w", err)
+	}
+
+	value, effects := a.Modifiers.Apply(modifier.TargetBuildDuration, "", a.subjects(req.Context), at, float64(base))
+
+	return time.Duration(math.Round(value)), effects, nil
+}
+
+func (a *InventoryActor) buildCost(req *message.BuildRequest, at time.Time) (map[string]uint64, []modifier.Effect) {
+	cost := make(map[string]uint64, len(req.Cost))
+	effects := make([]modifier.Effect, 0)
+
+	for resource, amount := range req.Cost {
+		value, resourceEffects := a.Modifiers.Apply(modifier.TargetBuildCost, resource, a.subjects(req.Context), at, float64(amount))
+		cost[resource] = uint64(math.Round(value))
+		effects = append(effects, resourceEffects...)
+	}
+
+	return cost, effects
+}
+
+func (a *InventoryActor) QueryModifiers(ctx context.Context, req *message.ModifierQuery, r *message.ModifierResponse) error {
+	if r == nil {
+		return fmt.Errorf("modifier query requires a modifier response")
+	}
+
+	now := a.Clock.Now()
+
+	r.TraceID = req.TraceID
+	r.Timestamp = timestamppb.New(now)
+
+	for _, m := range a.Modifiers.Active(a.subjects(req.Build.GetContext()), now) {
+		r.Active = append(r.Active, modifierMessage(m))
+	}
+
+	if req.Build == nil {
+		return nil
+	}
+
+	duration, durationEffects, err := a.buildDuration(req.Build, now)
+	if err != nil {
+		return err
+	}
+	cost, costEffects := a.buildCost(req.Build, now)
+
+	r.Duration = duration.String()
+	r.Cost = cost
+	for _, effect := range append(durationEffects, costEffects...) {
+		r.Effects = append(r.Effects, &message.ModifierEffect{
+			ModifierID: effect.Modifier.ID.String(),
+			Target:     string(effect.Modifier.Target),
+			Resource:   effect.Resource,
+			Before:     effect.Before,
+			After:      effect.After,
+		})
+	}
+
+	return nil
+}
+
+func modifierMessage(m modifier.Modifier) *message.Modifier {
+	msg := &message.Modifier{
+		ID:        m.ID.String(),
+		Name:      m.Name,
+		Scope:     string(m.Scope),
+		Target:    string(m.Target),
+		Resource:  m.Resource,
+		Operation: string(m.Operation),
+		Value:     m.Value,
+	}
+
+	if m.Subject != uuid.Nil {
+		msg.Subject = m.Subject.String()
+	}
+	if !m.Start.IsZero() {
+		msg.Start = timestamppb.New(m.Start)
+	}
+	if !m.End.IsZero() {
+		msg.End = timestamppb.New(m.End)
+	}
+
+	return msg
+}
@@ -0,0 +1,211 @@
+package actor
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	"github.com/google/uuid"
+	"google.golang.org/protobuf/proto"
+)
+
+var ErrQueueClosed = errors.New("queue is closed")
+
+// Queueable is any message a Queue can hold.
+type Queueable interface {
+	proto.Message
+}
+
+// Queue is a FIFO of messages. Consumers either block in Pop or wait on the
+// channel returned by Changed instead of polling.
+type Queue[T Queueable] struct {
+	mx *sync.Mutex
+
+	indices []uuid.UUID
+	items   map[uuid.UUID]T
+
+	changed chan struct{}
+	closed  bool
+}
+
+func NewQueue[T Queueable]() *Queue[T] {
+	return &Queue[T]{
+		mx: &sync.Mutex{},
+
+		indices: make([]uuid.UUID, 0),
+		items:   make(map[uuid.UUID]T),
+
+		changed: make(chan struct{}),
+	}
+}
+
+func (q *Queue[T]) len() int {
+	return len(q.indices)
+}
+
+// notify wakes everyone waiting on the current Changed channel. The caller must
+// hold q.mx.
+func (q *Queue[T]) notify() {
+	if q.closed {
+		return
+	}
+
+	close(q.changed)
+	q.changed = make(chan struct{})
+}
+
+func (q *Queue[T]) Len() int {
+	q.mx.Lock()
+	defer q.mx.Unlock()
+
+	return q.len()
+}
+
+// Changed returns a channel that is closed the next time an item is pushed or
+// removed, or when the queue is closed. Callers must call Changed again after
+// every wake-up.
+func (q *Queue[T]) Changed() <-chan struct{} {
+	q.mx.Lock()
+	defer q.mx.Unlock()
+
+	return q.changed
+}
+
+// Close wakes all waiting consumers and makes Pop and Push fail with
+// ErrQueueClosed. Closing a closed queue has no effect.
+func (q *Queue[T]) Close() {
+	q.mx.Lock()
+	defer q.mx.Unlock()
+
+	if q.closed {
+		return
+	}
+
+	q.closed = true
+	close(q.changed)
+}
+
+// Pop blocks until an item is available and removes it from the head of the
+// queue. It fails if ctx is done or the queue is closed first.
+func (q *Queue[T]) Pop(ctx context.Context) (T, error) {
+	for {
+		q.mx.Lock()
+		if q.closed {
+			q.mx.Unlock()
+			var zero T
+			return zero, ErrQueueClosed
+		}
+		if q.len() > 0 {
+			val := q.unshift()
+			q.mx.Unlock()
+			return val, nil
+		}
+		changed := q.changed
+		q.mx.Unlock()
+
+		select {
+		case <-changed:
+		case <-ctx.Done():
+			var zero T
+			return zero, ctx.Err()
+		}
+	}
+}
+
+func (q *Queue[T]) Unshift() T {
+	q.mx.Lock()
+	defer q.mx.Unlock()
+
+	return q.unshift()
+}
+
+func (q *Queue[T]) unshift() T {
+	len := q.len()
+	if len == 0 {
+		var zero T
+		return zero
+	}
+
+	index := q.indices[0]
+	val := q.items[index]
+
+	if len == 1 {
+		q.indices = make([]uuid.UUID, 0)
+	} else {
+		q.indices = q.indices[1:]
+	}
+
+	delete(q.items, index)
+	q.notify()
+
+	return val
+}
+
+// Push appends item to the queue and returns the new length.
+func (q *Queue[T]) Push(item T) (int, error) {
+	q.mx.Lock()
+	defer q.mx.Unlock()
+
+	if q.closed {
+		return q.len(), ErrQueueClosed
+	}
+
+	index := uuid.New()
+	q.indices = append(q.indices, index)
+	q.items[index] = item
+	q.notify()
+
+	return q.len(), nil
+}
+
+// Items returns the queued items in queue order.
+func (q *Queue[T]) Items() []T {
+	q.mx.Lock()
+	defer q.mx.Unlock()
+
+	items := make([]T, 0, q.len())
+	for _, index := range q.indices {
+		items = append(items, q.items[index])
+	}
+
+	return items
+}
+
+// Find returns the first queued item, in queue order, for which match
+// reports true.
+func (q *Queue[T]) Find(match func(T) bool) (T, bool) {
+	q.mx.Lock()
+	defer q.mx.Unlock()
+
+	for _, index := range q.indices {
+		if item := q.items[index]; match(item) {
+			return item, true
+		}
+	}
+
+	var zero T
+	return zero, false
+}
+
+// Remove takes the first queued item for which match reports true out of the
+// queue, preserving the order of the remaining items.
+func (q *Queue[T]) Remove(match func(T) bool) (T, bool) {
+	q.mx.Lock()
+	defer q.mx.Unlock()
+
+	for i, index := range q.indices {
+		item := q.items[index]
+		if !match(item) {
+			continue
+		}
+
+		q.indices = append(q.indices[:i:i], q.indices[i+1:]...)
+		delete(q.items, index)
+		q.notify()
+
+		return item, true
+	}
+
+	var zero T
+	return zero, false
+}
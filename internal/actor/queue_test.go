@@ -0,0 +1,213 @@
+package actor
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/gnarloqgames/ga-actor-poc/message"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+func TestQueueUnshift(t *testing.T) {
+	tests := []struct {
+		label         string
+		queue         []*message.BuildRequest
+		expectedValue *message.BuildRequest
+		expectedQueue []*message.BuildRequest
+	}{
+		{
+			label:         "empty",
+			queue:         make([]*message.BuildRequest, 0),
+			expectedValue: nil,
+			expectedQueue: make([]*message.BuildRequest, 0),
+		},
+		{
+			label: "one",
+			queue: []*message.BuildRequest{
+				{Name: "test_1"},
+			},
+			expectedValue: &message.BuildRequest{Name: "test_1"},
+			expectedQueue: make([]*message.BuildRequest, 0),
+		},
+		{
+			label: "more",
+			queue: []*message.BuildRequest{
+				{Name: "test_1"},
+				{Name: "test_2"},
+			},
+			expectedValue: &message.BuildRequest{Name: "test_1"},
+			expectedQueue: []*message.BuildRequest{
+				{Name: "test_2"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		queue := NewQueue[*message.BuildRequest]()
+		for _, item := range tt.queue {
+			queue.Push(item) //nolint
+		}
+
+		tf := func(t *testing.T) {
+			val := queue.Unshift()
+
+			if tt.expectedValue == nil {
+				require.Nil(t, val)
+			} else {
+				require.Equal(t, tt.expectedValue.Name, val.Name)
+			}
+
+			actualItems := make([]*message.BuildRequest, 0)
+			for _, item := range queue.items {
+				actualItems = append(actualItems, item)
+			}
+			require.ElementsMatch(t, tt.expectedQueue, actualItems)
+		}
+
+		t.Run(tt.label, tf)
+	}
+}
+
+func TestQueueRemove(t *testing.T) {
+	queue := NewQueue[*wrapperspb.StringValue]()
+	for _, value := range []string{"a", "b", "c"} {
+		queue.Push(wrapperspb.String(value)) //nolint
+	}
+
+	byValue := func(value string) func(*wrapperspb.StringValue) bool {
+		return func(item *wrapperspb.StringValue) bool { return item.Value == value }
+	}
+
+	removed, ok := queue.Remove(byValue("b"))
+	require.True(t, ok)
+	require.Equal(t, "b", removed.Value)
+
+	_, ok = queue.Remove(byValue("b"))
+	require.False(t, ok)
+
+	found, ok := queue.Find(byValue("c"))
+	require.True(t, ok)
+	require.Equal(t, "c", found.Value)
+
+	require.Equal(t, "a", queue.Unshift().Value)
+	require.Equal(t, "c", queue.Unshift().Value)
+	require.Nil(t, queue.Unshift())
+}
+
+func TestQueuePop(t *testing.T) {
+	tests := []struct {
+		label         string
+		act           func(queue *Queue[*message.BuildRequest], cancel context.CancelFunc)
+		expectedValue *message.BuildRequest
+		expectedError error
+	}{
+		{
+			label: "pushed",
+			act: func(queue *Queue[*message.BuildRequest], cancel context.CancelFunc) {
+				queue.Push(&message.BuildRequest{Name: "test_1"}) //nolint
+			},
+			expectedValue: &message.BuildRequest{Name: "test_1"},
+			expectedError: nil,
+		},
+		{
+			label: "cancelled",
+			act: func(queue *Queue[*message.BuildRequest], cancel context.CancelFunc) {
+				cancel()
+			},
+			expectedValue: nil,
+			expectedError: context.Canceled,
+		},
+		{
+			label: "closed",
+			act: func(queue *Queue[*message.BuildRequest], cancel context.CancelFunc) {
+				queue.Close()
+			},
+			expectedValue: nil,
+			expectedError: ErrQueueClosed,
+		},
+	}
+
+	for _, tt := range tests {
+		tf := func(t *testing.T) {
+			queue := NewQueue[*message.BuildRequest]()
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			type popResult struct {
+				val *message.BuildRequest
+				err error
+			}
+			result := make(chan popResult)
+			go func() {
+				val, err := queue.Pop(ctx)
+				result <- popResult{val, err}
+			}()
+
+			select {
+			case <-result:
+				t.Fatal("pop returned before the queue changed")
+			case <-time.After(10 * time.Millisecond):
+			}
+
+			tt.act(queue, cancel)
+
+			r := <-result
+			require.ErrorIs(t, r.err, tt.expectedError)
+			if tt.expectedValue == nil {
+				require.Nil(t, r.val)
+			} else {
+				require.Equal(t, tt.expectedValue.Name, r.val.Name)
+			}
+		}
+
+		t.Run(tt.label, tf)
+	}
+}
+
+func TestQueueChanged(t *testing.T) {
+	queue := NewQueue[*message.BuildRequest]()
+
+	changed := queue.Changed()
+	select {
+	case <-changed:
+		t.Fatal("changed fired without a change")
+	default:
+	}
+
+	_, err := queue.Push(&message.BuildRequest{Name: "test_1"})
+	require.NoError(t, err)
+	<-changed
+
+	changed = queue.Changed()
+	queue.Unshift()
+	<-changed
+
+	changed = queue.Changed()
+	queue.Close()
+	<-changed
+	<-queue.Changed()
+
+	_, err = queue.Push(&message.BuildRequest{Name: "test_2"})
+	require.ErrorIs(t, err, ErrQueueClosed)
+	require.Equal(t, 0, queue.Len())
+}
+
+func BenchmarkQueuePushPop(b *testing.B) {
+	queue := NewQueue[*message.BuildRequest]()
+	req := &message.BuildRequest{Name: "test"}
+	ctx := context.Background()
+
+	go func() {
+		for i := 0; i < b.N; i++ {
+			queue.Push(req) //nolint
+		}
+	}()
+
+	for i := 0; i < b.N; i++ {
+		if _, err := queue.Pop(ctx); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
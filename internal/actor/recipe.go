@@ -0,0 +1,195 @@
+package actor
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"math"
+	"sync"
+	"time"
+
+	"github.com/gnarloqgames/ga-actor-poc/internal/modifier"
+	"github.com/gnarloqgames/ga-actor-poc/message"
+	"github.com/google/uuid"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+// Recipe converts its inputs into its outputs over Duration. If Building is
+// set, the inventory must own a building with that name to craft it.
+type Recipe struct {
+	Name     string
+	Building string
+
+	Inputs   map[string]uint64
+	Outputs  map[string]uint64
+	Duration time.Duration
+}
+
+type RecipeBook struct {
+	mx *sync.Mutex
+
+	recipes map[string]Recipe
+}
+
+// DefaultRecipes is the recipe book inventories consult unless they are given
+// their own.
+var DefaultRecipes = NewRecipeBook()
+
+func NewRecipeBook() *RecipeBook {
+	return &RecipeBook{
+		mx: &sync.Mutex{},
+
+		recipes: make(map[string]Recipe),
+	}
+}
+
+func (b *RecipeBook) Add(recipe Recipe) {
+	b.mx.Lock()
+	defer b.mx.Unlock()
+
+	b.recipes[recipe.Name] = recipe
+}
+
+func (b *RecipeBook) Get(name string) (Recipe, bool) {
+	b.mx.Lock()
+	defer b.mx.Unlock()
+
+	recipe, ok := b.recipes[name]
+
+	return recipe, ok
+}
+
+func (a *InventoryActor) Craft(ctx context.Context, req *message.CraftRequest, res *message.BuildResponse) error {
+	recipe, ok := a.Recipes.Get(req.Recipe)
+	if !ok {
+		return fmt.Errorf("unknown recipe %s", req.Recipe)
+	}
+
+	if recipe.Building != "" {
+		if _, ok := a.Buildings.First(IndexName, recipe.Building); !ok {
+			return fmt.Errorf("recipe %s requires building %s", recipe.Name, recipe.Building)
+		}
+	}
+
+	if req.ID == "" {
+		req.ID = uuid.New().String()
+	} else if _, err := uuid.Parse(req.ID); err != nil {
+		return fmt.Errorf("invalid craft id: %w", err)
+	}
+
+	a.mx.Lock()
+	err := a.debit(recipe.Inputs)
+	if err == nil {
+		a.queuedCrafts[req.ID] = recipe
+	}
+	a.mx.Unlock()
+	if err != nil {
+		return err
+	}
+
+	newLen, err := a.CraftQueue.Push(req)
+	if err != nil {
+		a.mx.Lock()
+		delete(a.queuedCrafts, req.ID)
+		a.credit(recipe.Inputs)
+		a.mx.Unlock()
+
+		return err
+	}
+
+	a.mx.Lock()
+	a.save()
+	a.mx.Unlock()
+
+	slog.Info("added request to craft queue",
+		"id", req.ID,
+		"recipe", req.Recipe,
+		"len", newLen,
+	)
+
+	a.Executor.Go(a.nextCraft)
+
+	reply(res, req.TraceID, req.ID)
+
+	return nil
+}
+
+// nextCraft takes the next request off the craft queue and starts its timer
+// unless a craft is already in progress. The inputs of the recipe were debited
+// when the request was queued.
+func (a *InventoryActor) nextCraft() {
+	a.mx.Lock()
+	defer a.mx.Unlock()
+	defer a.save()
+
+	for a.crafting == nil && !a.stopped {
+		req := a.CraftQueue.Unshift()
+		if req == nil {
+			return
+		}
+
+		recipe, ok := a.queuedCrafts[req.ID]
+		delete(a.queuedCrafts, req.ID)
+		if !ok {
+			slog.Error("craft recipe not found", "id", req.ID, "recipe", req.Recipe)
+			continue
+		}
+
+		a.crafting = &activeCraft{request: req, recipe: recipe}
+		a.startCraft(recipe.Duration)
+	}
+}
+
+// startCraft starts the timer of the craft in progress, which finishes after
+// d. The caller must hold a.mx.
+func (a *InventoryActor) startCraft(d time.Duration) {
+	a.crafting.timer = NewTimerActorFunc(a.Clock, a.Executor, uuid.MustParse(a.crafting.request.ID), d, a.finishCraft)
+	a.adopt(a.crafting.timer)
+	a.wake(&a.crafting.activeTimer, d, &message.CraftTimerFired{CraftID: a.crafting.request.ID})
+}
+
+// CraftTimerFired is delivered once the craft in progress is due. It only
+// activates a passivated inventory, whose restored craft timer finishes the
+// craft.
+func (a *InventoryActor) CraftTimerFired(ctx context.Context, req *message.CraftTimerFired, res *emptypb.Empty) error {
+	slog.Debug("craft wake-up timer fired", "id", a.ID, "craft_id", req.CraftID)
+
+	return nil
+}
+
+// finishCraft credits the outputs of a craft whose timer is done and starts the
+// next one. Like build timers, craft timers are only cancelled when the
+// inventory stops.
+func (a *InventoryActor) finishCraft(timerReply TimerReply) {
+	a.release(timerReply)
+	if timerReply.Status != StatusDone {
+		return
+	}
+
+	a.mx.Lock()
+
+	if a.stopped || a.crafting == nil || a.crafting.timer == nil || a.crafting.timer.ID != timerReply.ID {
+		a.mx.Unlock()
+		return
+	}
+
+	req, recipe := a.crafting.request, a.crafting.recipe
+	a.unwake(&a.crafting.activeTimer)
+	a.crafting = nil
+
+	outputs := make(map[string]uint64, len(recipe.Outputs))
+	for resource, amount := range recipe.Outputs {
+		value, effects := a.Modifiers.Apply(modifier.TargetProduction, resource, a.subjects(req.Context), a.Clock.Now(), float64(amount))
+		for _, effect := range effects {
+			slog.Info("modifier applied to craft", append(effect.Modifier.Attributes(), "id", req.ID)...)
+		}
+		outputs[resource] = uint64(math.Round(value))
+	}
+	a.credit(outputs)
+
+	slog.Info("craft completed", "id", req.ID, "recipe", req.Recipe)
+
+	a.mx.Unlock()
+
+	a.nextCraft()
+}
@@ -0,0 +1,191 @@
+package actor
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/gnarloqgames/ga-actor-poc/internal/model"
+	"github.com/gnarloqgames/ga-actor-poc/internal/modifier"
+	"github.com/gnarloqgames/ga-actor-poc/internal/testkit"
+	"github.com/gnarloqgames/ga-actor-poc/message"
+	"github.com/gnarloqgames/ga-actor-poc/message/actorpb"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestRecipeBook() *RecipeBook {
+	book := NewRecipeBook()
+	book.Add(Recipe{
+		Name:     "plank",
+		Building: "sawmill",
+		Inputs:   map[string]uint64{"wood": 2},
+		Outputs:  map[string]uint64{"plank": 1},
+		Duration: 10 * time.Millisecond,
+	})
+
+	return book
+}
+
+func resourceAmount(a *InventoryActor, name string) uint {
+	resource, _ := a.Resources.First(IndexName, name)
+
+	return resource.amount
+}
+
+func TestCraft(t *testing.T) {
+	tests := []struct {
+		label          string
+		wood           uint
+		modifiers      []modifier.Modifier
+		expectedWood   uint
+		expectedPlanks uint
+	}{
+		{
+			label:          "crafted",
+			wood:           5,
+			expectedWood:   3,
+			expectedPlanks: 1,
+		},
+		{
+			label: "production modifier",
+			wood:  5,
+			modifiers: []modifier.Modifier{
+				{Scope: modifier.ScopeGlobal, Target: modifier.TargetProduction, Resource: "plank", Operation: modifier.OperationMultiply, Value: 3},
+			},
+			expectedWood:   3,
+			expectedPlanks: 3,
+		},
+	}
+
+	for _, tt := range tests {
+		tf := func(t *testing.T) {
+			a, fake := testkit.Spawn[*InventoryActor](t, actorpb.InventoryKind, InventoryActorFactory)
+			a.Recipes = newTestRecipeBook()
+			a.Modifiers = modifier.NewRegistry()
+			for _, m := range tt.modifiers {
+				a.Modifiers.Add(m)
+			}
+
+			sawmill := Building{id: uuid.New(), name: "sawmill"}
+			a.Buildings.Set(sawmill)
+			addTestResource(a, "wood", tt.wood)
+
+			err := a.Receive(context.Background(), &message.CraftRequest{Recipe: "plank"}, nil)
+			require.NoError(t, err)
+
+			// Inputs are debited when the craft is queued.
+			require.Equal(t, tt.expectedWood, resourceAmount(a, "wood"))
+
+			testkit.AwaitTimers(t, fake, 1)
+			fake.Advance(time.Second)
+
+			require.Eventually(t, func() bool {
+				return resourceAmount(a, "plank") == tt.expectedPlanks
+			}, time.Second, time.Millisecond)
+		}
+
+		t.Run(tt.label, tf)
+	}
+}
+
+func TestCraftErrors(t *testing.T) {
+	tests := []struct {
+		label   string
+		request *message.CraftRequest
+		wood    uint
+	}{
+		{
+			label:   "unknown recipe",
+			request: &message.CraftRequest{Recipe: "steel"},
+		},
+		{
+			label:   "missing building",
+			request: &message.CraftRequest{Recipe: "plank"},
+		},
+		{
+			label:   "invalid id",
+			request: &message.CraftRequest{ID: "plank-1", Recipe: "plank"},
+		},
+		{
+			label:   "inputs unavailable",
+			request: &message.CraftRequest{ID: uuid.NewString(), Recipe: "plank"},
+			wood:    1,
+		},
+	}
+
+	for _, tt := range tests {
+		tf := func(t *testing.T) {
+			a, _ := testkit.Spawn[*InventoryActor](t, actorpb.InventoryKind, InventoryActorFactory)
+			a.Recipes = newTestRecipeBook()
+			if tt.request.ID != "" {
+				sawmill := Building{id: uuid.New(), name: "sawmill"}
+				a.Buildings.Set(sawmill)
+			}
+			addTestResource(a, "wood", tt.wood)
+
+			err := a.Receive(context.Background(), tt.request, nil)
+			require.Error(t, err)
+			require.Zero(t, a.CraftQueue.Len())
+			require.Equal(t, tt.wood, resourceAmount(a, "wood"))
+		}
+
+		t.Run(tt.label, tf)
+	}
+}
+
+func TestCraftQueuedRecipe(t *testing.T) {
+	a, fake := testkit.Spawn[*InventoryActor](t, actorpb.InventoryKind, InventoryActorFactory)
+	a.Recipes = newTestRecipeBook()
+	a.Modifiers = modifier.NewRegistry()
+	a.Buildings.Set(Building{id: uuid.New(), name: "sawmill"})
+	addTestResource(a, "wood", 4)
+
+	for range 2 {
+		err := a.Receive(context.Background(), &message.CraftRequest{Recipe: "plank"}, nil)
+		require.NoError(t, err)
+	}
+	require.Zero(t, resourceAmount(a, "wood"))
+
+	// Queued crafts keep the recipe they were debited for.
+	a.Recipes = NewRecipeBook()
+
+	testkit.AwaitTimers(t, fake, 1)
+	fake.Advance(time.Second)
+	testkit.AwaitTimers(t, fake, 1)
+	fake.Advance(time.Second)
+
+	require.Eventually(t, func() bool {
+		return resourceAmount(a, "plank") == 2
+	}, time.Second, time.Millisecond)
+}
+
+func TestCraftTimer(t *testing.T) {
+	kit := testkit.New(t)
+	kit.Register(actorpb.InventoryKind, InventoryActorFactory)
+	address := model.Address{Kind: actorpb.InventoryKind, ID: uuid.New()}
+
+	a := kit.Actor(address).(*InventoryActor)
+	a.Recipes = newTestRecipeBook()
+	a.Buildings.Set(Building{id: uuid.New(), name: "sawmill"})
+	addTestResource(a, "wood", 2)
+
+	kit.Send(address, &message.CraftRequest{Recipe: "plank"})
+	testkit.AwaitTimers(t, kit.Clock, 1)
+
+	state := a.Inspect().(InventoryState)
+	require.NotNil(t, state.Crafting)
+	require.Equal(t, "plank", state.Crafting.Recipe)
+	require.Equal(t, StatusRunning, state.Crafting.Status)
+
+	children := kit.Manager.Children(address)
+	require.Len(t, children, 1)
+	require.Equal(t, TimerKind, children[0].Kind)
+
+	a.mx.Lock()
+	timer := a.crafting.timer
+	a.mx.Unlock()
+
+	require.NoError(t, kit.Manager.Stop(context.Background(), address))
+	require.Equal(t, StatusCancelled, timer.Status().Status)
+}
@@ -0,0 +1,170 @@
+package actor
+
+import (
+	"log/slog"
+
+	"github.com/gnarloqgames/ga-actor-poc/message"
+	"github.com/google/uuid"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// save replaces the saved state of the inventory with its buildings, resources
+// and builds and crafts, so that they survive passivation and restarts. A
+// destroyed inventory saves nothing, since it may already have been activated
+// again. The caller must hold a.mx.
+func (a *InventoryActor) save() {
+	if a.actx == nil || a.stopped {
+		return
+	}
+
+	snapshot := &message.InventorySnapshot{}
+
+	for _, building := range a.Buildings.All() {
+		snapshot.Buildings = append(snapshot.Buildings, &message.SavedItem{ID: building.id.String(), Name: building.name})
+	}
+	for _, resource := range a.Resources.All() {
+		snapshot.Resources = append(snapshot.Resources, &message.SavedItem{ID: resource.id.String(), Name: resource.name, Amount: uint64(resource.amount)})
+	}
+
+	if a.current != nil {
+		snapshot.Current = &message.SavedBuild{
+			Request: a.current.request,
+			FireAt:  timestamppb.New(a.current.fireAt),
+			WakeID:  a.current.wake.String(),
+		}
+	}
+	for _, req := range a.BuildQueue.Items() {
+		saved := &message.SavedBuild{Request: req, Cost: a.costs[req.ID]}
+		if remaining, ok := a.spedUp[req.ID]; ok {
+			saved.SpedUp = durationpb.New(remaining)
+		}
+		snapshot.BuildQueue = append(snapshot.BuildQueue, saved)
+	}
+
+	if a.crafting != nil {
+		snapshot.Crafting = &message.SavedCraft{
+			Request: a.crafting.request,
+			Recipe:  savedRecipe(a.crafting.recipe),
+			FireAt:  timestamppb.New(a.crafting.fireAt),
+			WakeID:  a.crafting.wake.String(),
+		}
+	}
+	for _, req := range a.CraftQueue.Items() {
+		snapshot.CraftQueue = append(snapshot.CraftQueue, &message.SavedCraft{Request: req, Recipe: savedRecipe(a.queuedCrafts[req.ID])})
+	}
+
+	if err := a.actx.SaveState(snapshot); err != nil {
+		slog.Error("failed to save inventory state", "id", a.ID, "error", err)
+	}
+}
+
+// restore loads the state the inventory saved before it was last destroyed.
+// The builds and crafts that were in progress get their timers back in resume,
+// outside of the factory activating the inventory.
+func (a *InventoryActor) restore() {
+	snapshot := &message.InventorySnapshot{}
+	ok, err := a.actx.LoadState(snapshot)
+	if err != nil {
+		slog.Error("failed to load inventory state", "id", a.ID, "error", err)
+		return
+	}
+	if !ok {
+		return
+	}
+
+	for _, saved := range snapshot.Buildings {
+		a.Buildings.Set(Building{id: savedID(saved.ID), name: saved.Name})
+	}
+	for _, saved := range snapshot.Resources {
+		a.Resources.Set(Resource{id: savedID(saved.ID), name: saved.Name, amount: uint(saved.Amount)})
+	}
+
+	if saved := snapshot.Current; saved != nil {
+		a.current = &activeBuild{
+			activeTimer: activeTimer{fireAt: saved.FireAt.AsTime(), wake: savedID(saved.WakeID)},
+			request:     saved.Request,
+		}
+	}
+	for _, saved := range snapshot.BuildQueue {
+		if _, err := a.BuildQueue.Push(saved.Request); err != nil {
+			slog.Error("failed to restore build", "id", saved.Request.GetID(), "error", err)
+			continue
+		}
+		if saved.Cost != nil {
+			a.costs[saved.Request.ID] = saved.Cost
+		}
+		if saved.SpedUp != nil {
+			a.spedUp[saved.Request.ID] = saved.SpedUp.AsDuration()
+		}
+	}
+
+	if saved := snapshot.Crafting; saved != nil {
+		a.crafting = &activeCraft{
+			activeTimer: activeTimer{fireAt: saved.FireAt.AsTime(), wake: savedID(saved.WakeID)},
+			request:     saved.Request,
+			recipe:      restoredRecipe(saved.Recipe),
+		}
+	}
+	for _, saved := range snapshot.CraftQueue {
+		if _, err := a.CraftQueue.Push(saved.Request); err != nil {
+			slog.Error("failed to restore craft", "id", saved.Request.GetID(), "error", err)
+			continue
+		}
+		a.queuedCrafts[saved.Request.ID] = restoredRecipe(saved.Recipe)
+	}
+
+	slog.Info("restored inventory", "id", a.ID, "builds", len(snapshot.BuildQueue), "crafts", len(snapshot.CraftQueue))
+
+	a.Executor.Go(a.resume)
+}
+
+// resume starts the timers of the restored build and craft in progress for the
+// time they had left, then the next queued ones.
+func (a *InventoryActor) resume() {
+	a.mx.Lock()
+	if !a.stopped {
+		now := a.Clock.Now()
+		if a.current != nil && a.current.timer == nil {
+			a.startBuild(max(a.current.fireAt.Sub(now), 0))
+		}
+		if a.crafting != nil && a.crafting.timer == nil {
+			a.startCraft(max(a.crafting.fireAt.Sub(now), 0))
+		}
+	}
+	a.mx.Unlock()
+
+	a.nextBuild()
+	a.nextCraft()
+}
+
+// savedID parses an ID of a snapshot. IDs that were never set, such as the
+// wake-up timer of a build that has none, are uuid.Nil.
+func savedID(id string) uuid.UUID {
+	parsed, err := uuid.Parse(id)
+	if err != nil {
+		return uuid.Nil
+	}
+
+	return parsed
+}
+
+func savedRecipe(recipe Recipe) *message.SavedRecipe {
+	return &message.SavedRecipe{
+		Name:     recipe.Name,
+		Building: recipe.Building,
+		Inputs:   recipe.Inputs,
+		Outputs:  recipe.Outputs,
+		Duration: durationpb.New(recipe.Duration),
+	}
+}
+
+func restoredRecipe(saved *message.SavedRecipe) Recipe {
+	return Recipe{
+		Name:     saved.GetName(),
+		Building: saved.GetBuilding(),
+		Inputs:   saved.GetInputs(),
+		Outputs:  saved.GetOutputs(),
+		Duration: saved.GetDuration().AsDuration(),
+	}
+}
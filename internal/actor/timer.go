@@ -1,31 +1,58 @@
 package actor
 
 import (
+	"context"
+	"fmt"
 	"log/slog"
+	"sync"
 	"time"
 
+	"github.com/gnarloqgames/ga-actor-poc/internal/clock"
+	"github.com/gnarloqgames/ga-actor-poc/internal/dispatch"
+	"github.com/gnarloqgames/ga-actor-poc/internal/executor"
+	"github.com/gnarloqgames/ga-actor-poc/internal/model"
 	"github.com/google/uuid"
+	"google.golang.org/protobuf/proto"
 )
 
+var _ model.Actor = (*TimerActor)(nil)
+
 type TimerStatus string
 
 const (
-	AttributeTimerID  string = "id"
-	AttributeQueueID  string = "queue_id"
-	AttributeDuration string = "duration"
-	AttributeStatus   string = "status"
+	TimerKind string = "timer"
+
+	AttributeTimerID   string = "id"
+	AttributeQueueID   string = "queue_id"
+	AttributeDuration  string = "duration"
+	AttributeStatus    string = "status"
+	AttributeRemaining string = "remaining"
 
 	StatusFailed    TimerStatus = "failed"
 	StatusDone      TimerStatus = "done"
 	StatusCancelled TimerStatus = "cancelled"
+	StatusRunning   TimerStatus = "running"
+	StatusPaused    TimerStatus = "paused"
 )
 
+// Final reports whether a timer in this status will never fire again.
+func (s TimerStatus) Final() bool {
+	return s == StatusFailed || s == StatusDone || s == StatusCancelled
+}
+
 type TimerActor struct {
 	ID       uuid.UUID
 	QueueID  uuid.UUID
 	Duration time.Duration
 
-	stop chan struct{}
+	mx         *sync.Mutex
+	clock      clock.Clock
+	status     TimerStatus
+	remaining  time.Duration
+	generation int
+	timer      clock.Timer
+	executor   executor.Executor
+	onFinish   func(TimerReply)
 }
 
 func (t TimerActor) Attributes() []any {
@@ -37,9 +64,10 @@ func (t TimerActor) Attributes() []any {
 }
 
 type TimerReply struct {
-	ID      uuid.UUID
-	QueueID uuid.UUID
-	Status  TimerStatus
+	ID        uuid.UUID
+	QueueID   uuid.UUID
+	Status    TimerStatus
+	Remaining time.Duration
 }
 
 func (t TimerReply) Attributes() []any {
@@ -47,52 +75,249 @@ func (t TimerReply) Attributes() []any {
 		AttributeTimerID, t.ID.String(),
 		AttributeQueueID, t.QueueID.String(),
 		AttributeStatus, t.Status,
+		AttributeRemaining, t.Remaining.String(),
 	}
 }
 
+// NewTimerActor registers a timer with the real clock. Exactly one TimerReply
+// with a final status is sent on replyChan once the timer fires or is stopped.
+// Every other operation reports its outcome as its return value.
 func NewTimerActor(queueID uuid.UUID, duration time.Duration, replyChan chan TimerReply) *TimerActor {
+	return NewTimerActorWithClock(clock.Real, queueID, duration, replyChan)
+}
+
+// NewTimerActorWithClock is NewTimerActor running on the given clock.
+func NewTimerActorWithClock(c clock.Clock, queueID uuid.UUID, duration time.Duration, replyChan chan TimerReply) *TimerActor {
+	return NewTimerActorFunc(c, executor.Default, queueID, duration, func(reply TimerReply) {
+		replyChan <- reply
+	})
+}
+
+// NewTimerActorFunc registers a timer with the given clock and calls onFinish
+// through e exactly once, with the final TimerReply, once the timer fires or is
+// stopped.
+func NewTimerActorFunc(c clock.Clock, e executor.Executor, queueID uuid.UUID, duration time.Duration, onFinish func(TimerReply)) *TimerActor {
 	actor := &TimerActor{
 		ID:       uuid.New(),
 		QueueID:  queueID,
 		Duration: duration,
-		stop:     make(chan struct{}),
+
+		mx:       &sync.Mutex{},
+		clock:    c,
+		status:   StatusRunning,
+		executor: e,
+		onFinish: onFinish,
 	}
-	actorAttributes := actor.Attributes()
 
-	go func() {
-		timer := time.NewTimer(duration)
+	actor.mx.Lock()
+	defer actor.mx.Unlock()
 
-		reply := TimerReply{
-			ID:      actor.ID,
-			QueueID: actor.QueueID,
-			Status:  StatusFailed,
-		}
+	actor.start(duration)
+
+	return actor
+}
+
+func (t *TimerActor) GetID() uuid.UUID {
+	return t.ID
+}
+
+func (t *TimerActor) GetKind() string {
+	return TimerKind
+}
+
+func (t *TimerActor) Start(ctx context.Context) {}
+
+// Destroy stops the timer, as when its parent stops.
+func (t *TimerActor) Destroy(ctx context.Context) {
+	t.Stop()
+}
+
+// Receive rejects every message. Timers are driven by their owner through
+// their methods.
+func (t *TimerActor) Receive(ctx context.Context, msg proto.Message, res proto.Message) error {
+	return fmt.Errorf("%w: timers receive no messages", dispatch.ErrUnhandled)
+}
+
+// start registers the timer with the clock. The caller must hold t.mx.
+func (t *TimerActor) start(d time.Duration) {
+	t.generation++
+	generation := t.generation
+	attributes := t.Attributes()
+
+	t.timer = t.clock.AfterFunc(d, func() {
+		t.mx.Lock()
+		defer t.mx.Unlock()
 
-	Loop:
-		for {
-			select {
-			case <-timer.C:
-				slog.Info("timer expired",
-					actorAttributes...,
-				)
-				reply.Status = StatusDone
-				break Loop
-			case <-actor.stop:
-				slog.Info("timer received stop signal",
-					actorAttributes...,
-				)
-				timer.Stop()
-				reply.Status = StatusCancelled
-				break Loop
-			}
+		if t.generation != generation || t.status != StatusRunning {
+			return
 		}
 
-		replyChan <- reply
-	}()
+		slog.Info("timer expired",
+			attributes...,
+		)
+		t.finish(StatusDone)
+	})
+}
 
-	return actor
+// finish moves the timer to a final status and sends the reply for it. The
+// caller must hold t.mx.
+func (t *TimerActor) finish(status TimerStatus) TimerReply {
+	t.status = status
+	reply := t.reply()
+
+	t.executor.Go(func() { t.onFinish(reply) })
+
+	return reply
 }
 
-func (t *TimerActor) Stop() {
-	t.stop <- struct{}{}
+// reply describes the current state of the timer. The caller must hold t.mx.
+func (t *TimerActor) reply() TimerReply {
+	reply := TimerReply{
+		ID:      t.ID,
+		QueueID: t.QueueID,
+		Status:  t.status,
+	}
+
+	switch t.status {
+	case StatusRunning:
+		reply.Remaining = t.timer.Remaining()
+	case StatusPaused:
+		reply.Remaining = t.remaining
+	}
+
+	return reply
+}
+
+// halt takes a running timer off the clock and records its remaining time.
+// It reports false if the clock has already fired the timer, in which case the
+// caller finishes it. The caller must hold t.mx.
+func (t *TimerActor) halt() bool {
+	remaining := t.timer.Remaining()
+	if !t.timer.Stop() {
+		return false
+	}
+
+	t.remaining = remaining
+
+	return true
+}
+
+// Status returns the current state of the timer, including its remaining
+// time while it is running or paused.
+func (t *TimerActor) Status() TimerReply {
+	t.mx.Lock()
+	defer t.mx.Unlock()
+
+	return t.reply()
+}
+
+// Stop cancels a running or paused timer. It never blocks and stopping a timer
+// that has already finished only reports its final status.
+func (t *TimerActor) Stop() TimerReply {
+	t.mx.Lock()
+	defer t.mx.Unlock()
+
+	if t.status.Final() {
+		return t.reply()
+	}
+	if t.status == StatusRunning && !t.halt() {
+		return t.finish(StatusDone)
+	}
+
+	slog.Info("timer received stop signal",
+		t.Attributes()...,
+	)
+
+	return t.finish(StatusCancelled)
+}
+
+// Pause freezes a running timer, keeping its remaining time until Resume.
+func (t *TimerActor) Pause() TimerReply {
+	t.mx.Lock()
+	defer t.mx.Unlock()
+
+	if t.status != StatusRunning {
+		return t.reply()
+	}
+	if !t.halt() {
+		return t.finish(StatusDone)
+	}
+
+	t.status = StatusPaused
+
+	slog.Info("timer paused",
+		append(t.Attributes(), AttributeRemaining, t.remaining.String())...,
+	)
+
+	return t.reply()
+}
+
+// Resume restarts a paused timer with the time it had left when it was paused.
+func (t *TimerActor) Resume() TimerReply {
+	t.mx.Lock()
+	defer t.mx.Unlock()
+
+	if t.status != StatusPaused {
+		return t.reply()
+	}
+
+	t.status = StatusRunning
+	t.start(t.remaining)
+
+	slog.Info("timer resumed",
+		append(t.Attributes(), AttributeRemaining, t.remaining.String())...,
+	)
+
+	return t.reply()
+}
+
+// Extend adds d to the remaining time of a running or paused timer.
+func (t *TimerActor) Extend(d time.Duration) TimerReply {
+	return t.adjust(func(remaining time.Duration) time.Duration {
+		return remaining + d
+	})
+}
+
+// Shorten takes d off the remaining time of a running or paused timer. A
+// running timer whose remaining time drops to zero fires immediately.
+func (t *TimerActor) Shorten(d time.Duration) TimerReply {
+	return t.adjust(func(remaining time.Duration) time.Duration {
+		return remaining - d
+	})
+}
+
+// Reschedule makes the timer fire after remaining has elapsed, counting from
+// now. A remaining duration of zero or less fires the timer immediately. It
+// reports false if the timer has already fired or been stopped.
+func (t *TimerActor) Reschedule(remaining time.Duration) bool {
+	reply := t.adjust(func(time.Duration) time.Duration {
+		return remaining
+	})
+
+	return !reply.Status.Final()
+}
+
+func (t *TimerActor) adjust(change func(remaining time.Duration) time.Duration) TimerReply {
+	t.mx.Lock()
+	defer t.mx.Unlock()
+
+	switch t.status {
+	case StatusPaused:
+		t.remaining = max(change(t.remaining), 0)
+	case StatusRunning:
+		remaining := max(change(t.timer.Remaining()), 0)
+		if !t.timer.Reschedule(remaining) {
+			return t.finish(StatusDone)
+		}
+	default:
+		return t.reply()
+	}
+
+	reply := t.reply()
+
+	slog.Info("timer rescheduled",
+		append(t.Attributes(), AttributeRemaining, reply.Remaining.String())...,
+	)
+
+	return reply
 }
@@ -1,89 +1,283 @@
 package actor
 
 import (
-	"context"
-	"log/slog"
 	"testing"
 	"time"
 
+	"github.com/gnarloqgames/ga-actor-poc/internal/clock"
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/require"
 )
 
+// expectTimerReply waits for the final reply of a timer. Replies are sent from
+// their own goroutine, so this only waits for that handoff, never for the
+// clock.
+func expectTimerReply(t *testing.T, reply chan TimerReply, expected TimerStatus) {
+	t.Helper()
+
+	select {
+	case r := <-reply:
+		require.Equal(t, expected, r.Status)
+	case <-time.After(time.Second):
+		t.Fatalf("no %s reply received", expected)
+	}
+}
+
 func TestTimer(t *testing.T) {
 	tests := []struct {
 		label          string
 		duration       time.Duration
-		timeout        time.Duration
+		elapsed        time.Duration
 		cancelAfter    time.Duration
 		expectedStatus TimerStatus
-		expectedError  error
 	}{
 		{
 			label:          "done",
 			duration:       100 * time.Millisecond,
-			timeout:        200 * time.Millisecond,
+			elapsed:        200 * time.Millisecond,
 			cancelAfter:    0,
 			expectedStatus: StatusDone,
-			expectedError:  nil,
 		},
 		{
 			label:          "deadline",
 			duration:       600 * time.Millisecond,
-			timeout:        100 * time.Millisecond,
+			elapsed:        100 * time.Millisecond,
 			cancelAfter:    0,
-			expectedStatus: StatusFailed,
-			expectedError:  context.DeadlineExceeded,
+			expectedStatus: StatusRunning,
 		},
 		{
 			label:          "cancelled",
 			duration:       500 * time.Millisecond,
-			timeout:        200 * time.Millisecond,
+			elapsed:        200 * time.Millisecond,
 			cancelAfter:    100 * time.Millisecond,
 			expectedStatus: StatusCancelled,
-			expectedError:  nil,
+		},
+		{
+			label:          "multi-hour build",
+			duration:       36 * time.Hour,
+			elapsed:        48 * time.Hour,
+			cancelAfter:    0,
+			expectedStatus: StatusDone,
 		},
 	}
 
 	for _, tt := range tests {
 		tf := func(t *testing.T) {
-			queue_id := uuid.New()
+			fake := clock.NewFake(time.Now())
 			reply := make(chan TimerReply)
 
-			ctx, cancel := context.WithTimeout(context.Background(), tt.timeout)
-			defer cancel()
-
-			a := NewTimerActor(queue_id, tt.duration, reply)
+			a := NewTimerActorWithClock(fake, uuid.New(), tt.duration, reply)
 
 			if tt.cancelAfter > 0 {
-				go func() {
-					t := time.NewTimer(tt.cancelAfter)
-					<-t.C
-					a.Stop()
-				}()
+				fake.Advance(tt.cancelAfter)
+				a.Stop()
+			}
+			fake.Advance(tt.elapsed - tt.cancelAfter)
+
+			require.Equal(t, tt.expectedStatus, a.Status().Status)
+			if tt.expectedStatus.Final() {
+				expectTimerReply(t, reply, tt.expectedStatus)
+			}
+		}
+
+		t.Run(tt.label, tf)
+	}
+}
+
+func TestTimerReschedule(t *testing.T) {
+	tests := []struct {
+		label          string
+		duration       time.Duration
+		remaining      time.Duration
+		elapsed        time.Duration
+		expectedStatus TimerStatus
+	}{
+		{
+			label:          "shorten",
+			duration:       time.Minute,
+			remaining:      50 * time.Millisecond,
+			elapsed:        50 * time.Millisecond,
+			expectedStatus: StatusDone,
+		},
+		{
+			label:          "immediate",
+			duration:       time.Minute,
+			remaining:      0,
+			elapsed:        0,
+			expectedStatus: StatusDone,
+		},
+		{
+			label:          "extend",
+			duration:       50 * time.Millisecond,
+			remaining:      time.Minute,
+			elapsed:        59 * time.Second,
+			expectedStatus: StatusRunning,
+		},
+	}
+
+	for _, tt := range tests {
+		tf := func(t *testing.T) {
+			fake := clock.NewFake(time.Now())
+			reply := make(chan TimerReply)
+
+			a := NewTimerActorWithClock(fake, uuid.New(), tt.duration, reply)
+			require.True(t, a.Reschedule(tt.remaining))
+
+			fake.Advance(tt.elapsed)
+
+			require.Equal(t, tt.expectedStatus, a.Status().Status)
+			if tt.expectedStatus.Final() {
+				expectTimerReply(t, reply, tt.expectedStatus)
 			}
+		}
+
+		t.Run(tt.label, tf)
+	}
+}
+
+func TestTimerRescheduleAfterDone(t *testing.T) {
+	fake := clock.NewFake(time.Now())
+	reply := make(chan TimerReply)
+	a := NewTimerActorWithClock(fake, uuid.New(), time.Millisecond, reply)
+
+	fake.Advance(time.Millisecond)
+	expectTimerReply(t, reply, StatusDone)
+
+	require.False(t, a.Reschedule(time.Minute))
+}
+
+func TestTimerPauseResume(t *testing.T) {
+	fake := clock.NewFake(time.Now())
+	reply := make(chan TimerReply)
+	a := NewTimerActorWithClock(fake, uuid.New(), 100*time.Millisecond, reply)
+
+	fake.Advance(40 * time.Millisecond)
 
-			actualStatus := StatusFailed
-			var actualError error
-		Loop:
-			for {
-				select {
-				case r := <-reply:
-					actualStatus = r.Status
-					slog.Info("reply received", r.Attributes()...)
-					break Loop
-				case <-ctx.Done():
-					if err := ctx.Err(); err != nil {
-						actualError = err
-					}
-					break Loop
-				}
+	paused := a.Pause()
+	require.Equal(t, StatusPaused, paused.Status)
+	require.Equal(t, 60*time.Millisecond, paused.Remaining)
+	require.Equal(t, StatusPaused, a.Pause().Status)
+
+	fake.Advance(time.Hour)
+	require.Equal(t, paused, a.Status())
+
+	resumed := a.Resume()
+	require.Equal(t, StatusRunning, resumed.Status)
+	require.Equal(t, 60*time.Millisecond, resumed.Remaining)
+	require.Equal(t, StatusRunning, a.Resume().Status)
+
+	fake.Advance(59 * time.Millisecond)
+	require.Equal(t, StatusRunning, a.Status().Status)
+
+	fake.Advance(time.Millisecond)
+	expectTimerReply(t, reply, StatusDone)
+	require.Equal(t, StatusDone, a.Resume().Status)
+}
+
+func TestTimerExtendShorten(t *testing.T) {
+	tests := []struct {
+		label             string
+		pause             bool
+		change            func(a *TimerActor) TimerReply
+		expectedRemaining time.Duration
+	}{
+		{
+			label:             "extend running",
+			change:            func(a *TimerActor) TimerReply { return a.Extend(time.Hour) },
+			expectedRemaining: 2 * time.Hour,
+		},
+		{
+			label:             "shorten running",
+			change:            func(a *TimerActor) TimerReply { return a.Shorten(30 * time.Minute) },
+			expectedRemaining: 30 * time.Minute,
+		},
+		{
+			label:             "extend paused",
+			pause:             true,
+			change:            func(a *TimerActor) TimerReply { return a.Extend(time.Hour) },
+			expectedRemaining: 2 * time.Hour,
+		},
+		{
+			label:             "shorten paused below zero",
+			pause:             true,
+			change:            func(a *TimerActor) TimerReply { return a.Shorten(2 * time.Hour) },
+			expectedRemaining: 0,
+		},
+	}
+
+	for _, tt := range tests {
+		tf := func(t *testing.T) {
+			fake := clock.NewFake(time.Now())
+			a := NewTimerActorWithClock(fake, uuid.New(), time.Hour, make(chan TimerReply, 1))
+
+			if tt.pause {
+				a.Pause()
 			}
 
-			cancel()
+			r := tt.change(a)
+			require.Equal(t, tt.expectedRemaining, r.Remaining)
+			require.Equal(t, tt.expectedRemaining, a.Status().Remaining)
+		}
+
+		t.Run(tt.label, tf)
+	}
+}
+
+func TestTimerShortenFires(t *testing.T) {
+	fake := clock.NewFake(time.Now())
+	reply := make(chan TimerReply)
+	a := NewTimerActorWithClock(fake, uuid.New(), time.Hour, reply)
 
-			require.Equal(t, tt.expectedStatus, actualStatus)
-			require.Equal(t, tt.expectedError, actualError)
+	a.Shorten(2 * time.Hour)
+	fake.Advance(0)
+
+	expectTimerReply(t, reply, StatusDone)
+}
+
+func TestTimerStopIdempotent(t *testing.T) {
+	tests := []struct {
+		label          string
+		prepare        func(t *testing.T, fake *clock.Fake, a *TimerActor, reply chan TimerReply)
+		expectedStatus TimerStatus
+	}{
+		{
+			label:          "running",
+			prepare:        func(t *testing.T, fake *clock.Fake, a *TimerActor, reply chan TimerReply) {},
+			expectedStatus: StatusCancelled,
+		},
+		{
+			label: "paused",
+			prepare: func(t *testing.T, fake *clock.Fake, a *TimerActor, reply chan TimerReply) {
+				a.Pause()
+			},
+			expectedStatus: StatusCancelled,
+		},
+		{
+			label: "done",
+			prepare: func(t *testing.T, fake *clock.Fake, a *TimerActor, reply chan TimerReply) {
+				fake.Advance(time.Hour)
+				expectTimerReply(t, reply, StatusDone)
+			},
+			expectedStatus: StatusDone,
+		},
+	}
+
+	for _, tt := range tests {
+		tf := func(t *testing.T) {
+			fake := clock.NewFake(time.Now())
+			reply := make(chan TimerReply, 2)
+			a := NewTimerActorWithClock(fake, uuid.New(), time.Hour, reply)
+			tt.prepare(t, fake, a, reply)
+
+			require.Equal(t, tt.expectedStatus, a.Stop().Status)
+			require.Equal(t, tt.expectedStatus, a.Stop().Status)
+			fake.Advance(time.Hour)
+
+			if tt.expectedStatus == StatusCancelled {
+				expectTimerReply(t, reply, StatusCancelled)
+			}
+			require.Equal(t, 0, fake.Len())
+			require.Empty(t, reply)
 		}
 
 		t.Run(tt.label, tf)
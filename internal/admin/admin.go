@@ -0,0 +1,251 @@
+package admin
+
+import (
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"slices"
+	"time"
+
+	"github.com/gnarloqgames/ga-actor-poc/internal/manager"
+	"github.com/gnarloqgames/ga-actor-poc/internal/model"
+	"github.com/google/uuid"
+)
+
+type KindResponse struct {
+	Kind         string         `json:"kind"`
+	Active       int            `json:"active"`
+	Activations  uint64         `json:"activations"`
+	Passivations uint64         `json:"passivations"`
+	Mailbox      int64          `json:"mailbox"`
+	Queues       map[string]int `json:"queues"`
+}
+
+type AddressResponse struct {
+	Kind string `json:"kind"`
+	ID   string `json:"id"`
+}
+
+type ActorResponse struct {
+	Kind         string    `json:"kind"`
+	ID           string    `json:"id"`
+	Mailbox      int64     `json:"mailbox"`
+	LastActivity time.Time `json:"last_activity"`
+
+	Parent   *AddressResponse  `json:"parent,omitempty"`
+	Children []AddressResponse `json:"children,omitempty"`
+	// State is set for actors implementing model.Inspector.
+	State any `json:"state,omitempty"`
+}
+
+type ActorsResponse struct {
+	Kind   string          `json:"kind"`
+	Count  int             `json:"count"`
+	Actors []ActorResponse `json:"actors"`
+}
+
+type ErrorResponse struct {
+	Error string `json:"error"`
+}
+
+func addressResponse(address model.Address) AddressResponse {
+	return AddressResponse{Kind: address.Kind, ID: address.ID.String()}
+}
+
+func actorResponse(stats manager.ActorStats) ActorResponse {
+	return ActorResponse{
+		Kind:         stats.Address.Kind,
+		ID:           stats.Address.ID.String(),
+		Mailbox:      stats.Mailbox,
+		LastActivity: stats.LastActivity,
+	}
+}
+
+type handler struct {
+	manager *manager.Manager
+}
+
+// NewMux returns the routes of the admin API of m:
+//
+//	GET  /kinds                                 registered kinds and their counts
+//	GET  /kinds/{kind}/actors                   running actors of a kind
+//	GET  /kinds/{kind}/actors/{id}              an actor, its family and state
+//	POST /kinds/{kind}/actors/{id}/passivate    passivates an actor
+//	POST /kinds/{kind}/actors/{id}/stop         stops an actor
+//
+// Looking actors up never activates them. The API can stop any actor, so it
+// must only be served on an internal address. Callers can add routes, such
+// as metrics, to the returned mux.
+func NewMux(m *manager.Manager) *http.ServeMux {
+	h := &handler{manager: m}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /kinds", h.kinds)
+	mux.HandleFunc("GET /kinds/{kind}/actors", h.actors)
+	mux.HandleFunc("GET /kinds/{kind}/actors/{id}", h.actor)
+	mux.HandleFunc("POST /kinds/{kind}/actors/{id}/passivate", h.passivate)
+	mux.HandleFunc("POST /kinds/{kind}/actors/{id}/stop", h.stop)
+
+	return mux
+}
+
+// NewServer returns a server for the admin API of m at addr.
+func NewServer(addr string, m *manager.Manager) *http.Server {
+	return &http.Server{
+		Addr:              addr,
+		Handler:           NewMux(m),
+		ReadHeaderTimeout: 5 * time.Second,
+	}
+}
+
+func (h *handler) kinds(w http.ResponseWriter, r *http.Request) {
+	stats := h.manager.Stats()
+
+	kinds := make([]KindResponse, 0, len(stats.Kinds))
+	for _, kind := range stats.Kinds {
+		kinds = append(kinds, KindResponse{
+			Kind:         kind.Kind,
+			Active:       kind.Active,
+			Activations:  kind.Activations,
+			Passivations: kind.Passivations,
+			Mailbox:      kind.Mailbox,
+			Queues:       kind.Queues,
+		})
+	}
+
+	writeJSON(w, http.StatusOK, kinds)
+}
+
+func (h *handler) actors(w http.ResponseWriter, r *http.Request) {
+	kind, ok := h.kind(w, r)
+	if !ok {
+		return
+	}
+
+	stats, err := h.manager.ActorStats(kind)
+	if err != nil {
+		writeError(w, http.StatusNotFound, err)
+		return
+	}
+
+	actors := make([]ActorResponse, 0, len(stats))
+	for _, s := range stats {
+		actors = append(actors, actorResponse(s))
+	}
+
+	writeJSON(w, http.StatusOK, ActorsResponse{
+		Kind:   kind,
+		Count:  len(actors),
+		Actors: actors,
+	})
+}
+
+func (h *handler) actor(w http.ResponseWriter, r *http.Request) {
+	address, ok := h.address(w, r)
+	if !ok {
+		return
+	}
+
+	actor, stats, err := h.manager.Lookup(address)
+	if err != nil {
+		writeError(w, statusOf(err), err)
+		return
+	}
+
+	res := actorResponse(stats)
+	if parent, ok := h.manager.Parent(address); ok {
+		parentRes := addressResponse(parent)
+		res.Parent = &parentRes
+	}
+	for _, child := range h.manager.Children(address) {
+		res.Children = append(res.Children, addressResponse(child))
+	}
+	if inspector, ok := actor.(model.Inspector); ok {
+		res.State = inspector.Inspect()
+	}
+
+	writeJSON(w, http.StatusOK, res)
+}
+
+func (h *handler) passivate(w http.ResponseWriter, r *http.Request) {
+	address, ok := h.address(w, r)
+	if !ok {
+		return
+	}
+
+	if err := h.manager.Passivate(r.Context(), address); err != nil {
+		writeError(w, statusOf(err), err)
+		return
+	}
+
+	slog.Info("actor passivated by admin", "actor_kind", address.Kind, "actor_id", address.ID)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *handler) stop(w http.ResponseWriter, r *http.Request) {
+	address, ok := h.address(w, r)
+	if !ok {
+		return
+	}
+
+	if err := h.manager.Stop(r.Context(), address); err != nil {
+		writeError(w, statusOf(err), err)
+		return
+	}
+
+	slog.Info("actor stopped by admin", "actor_kind", address.Kind, "actor_id", address.ID)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// kind returns the kind in the path of r, answering 404 if the manager does
+// not know it.
+func (h *handler) kind(w http.ResponseWriter, r *http.Request) (string, bool) {
+	kind := r.PathValue("kind")
+	if !slices.Contains(h.manager.Kinds(), kind) {
+		writeError(w, http.StatusNotFound, errors.New("kind "+kind+" is not registered"))
+		return "", false
+	}
+
+	return kind, true
+}
+
+// address returns the actor address in the path of r, answering 400 if its ID
+// is not a UUID.
+func (h *handler) address(w http.ResponseWriter, r *http.Request) (model.Address, bool) {
+	kind, ok := h.kind(w, r)
+	if !ok {
+		return model.Address{}, false
+	}
+
+	id, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return model.Address{}, false
+	}
+
+	return model.Address{Kind: kind, ID: id}, true
+}
+
+// statusOf maps the errors of actor lookups and lifecycle changes to HTTP
+// statuses.
+func statusOf(err error) int {
+	if errors.Is(err, model.ErrNotActive) {
+		return http.StatusNotFound
+	}
+
+	return http.StatusConflict
+}
+
+func writeJSON(w http.ResponseWriter, status int, body any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+
+	if err := json.NewEncoder(w).Encode(body); err != nil {
+		slog.Error("failed to write admin response", "error", err)
+	}
+}
+
+func writeError(w http.ResponseWriter, status int, err error) {
+	writeJSON(w, status, ErrorResponse{Error: err.Error()})
+}
@@ -0,0 +1,193 @@
+package admin
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gnarloqgames/ga-actor-poc/internal/actor"
+	"github.com/gnarloqgames/ga-actor-poc/internal/model"
+	"github.com/gnarloqgames/ga-actor-poc/internal/testkit"
+	"github.com/gnarloqgames/ga-actor-poc/message"
+	"github.com/gnarloqgames/ga-actor-poc/message/actorpb"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+func do(t *testing.T, server *httptest.Server, method string, path string, body any) int {
+	t.Helper()
+
+	req, err := http.NewRequest(method, server.URL+path, nil)
+	require.NoError(t, err)
+
+	res, err := server.Client().Do(req)
+	require.NoError(t, err)
+	defer res.Body.Close()
+
+	if body != nil {
+		require.Equal(t, "application/json", res.Header.Get("Content-Type"))
+		require.NoError(t, json.NewDecoder(res.Body).Decode(body))
+	}
+
+	return res.StatusCode
+}
+
+func newInventoryServer(t *testing.T) (*testkit.Kit, *httptest.Server, model.Address) {
+	t.Helper()
+
+	kit := testkit.New(t)
+	kit.Register(actorpb.InventoryKind, actor.InventoryActorFactory)
+	kit.Probe("probe")
+
+	inventory := model.Address{Kind: actorpb.InventoryKind, ID: uuid.New()}
+	for _, name := range []string{"farm", "mill"} {
+		kit.Ask(inventory, &message.BuildRequest{Name: name, Duration: "1m"}, &message.BuildResponse{})
+	}
+
+	// The farm leaves the queue once the inventory starts building it.
+	require.Eventually(t, func() bool {
+		return len(kit.Manager.Children(inventory)) == 1
+	}, testkit.DefaultTimeout, time.Millisecond)
+
+	server := httptest.NewServer(NewMux(kit.Manager))
+	t.Cleanup(server.Close)
+
+	return kit, server, inventory
+}
+
+func TestKinds(t *testing.T) {
+	_, server, _ := newInventoryServer(t)
+
+	var kinds []KindResponse
+	require.Equal(t, http.StatusOK, do(t, server, http.MethodGet, "/kinds", &kinds))
+	require.Equal(t, []KindResponse{
+		{Kind: actorpb.InventoryKind, Active: 1, Activations: 1, Queues: map[string]int{actor.QueueBuild: 1, actor.QueueCraft: 0}},
+		{Kind: "probe", Queues: map[string]int{}},
+		{Kind: actor.TimerKind, Active: 1, Activations: 1, Queues: map[string]int{}},
+	}, kinds)
+}
+
+func TestActors(t *testing.T) {
+	kit, server, inventory := newInventoryServer(t)
+
+	var actors ActorsResponse
+	require.Equal(t, http.StatusOK, do(t, server, http.MethodGet, "/kinds/inventory/actors", &actors))
+	require.Equal(t, actorpb.InventoryKind, actors.Kind)
+	require.Equal(t, 1, actors.Count)
+	require.Equal(t, inventory.ID.String(), actors.Actors[0].ID)
+	require.Equal(t, int64(0), actors.Actors[0].Mailbox)
+	require.True(t, testkit.Epoch.Equal(actors.Actors[0].LastActivity))
+
+	kit.Advance(10 * time.Second)
+
+	var res struct {
+		ActorResponse
+		State actor.InventoryState `json:"state"`
+	}
+	require.Equal(t, http.StatusOK, do(t, server, http.MethodGet, "/kinds/inventory/actors/"+inventory.ID.String(), &res))
+	require.Nil(t, res.Parent)
+	require.Len(t, res.Children, 1)
+	require.Equal(t, actor.TimerKind, res.Children[0].Kind)
+
+	require.Empty(t, res.State.Buildings)
+	require.Equal(t, "farm", res.State.Current.Name)
+	require.Equal(t, actor.StatusRunning, res.State.Current.Status)
+	require.Equal(t, "50s", res.State.Current.Remaining)
+	require.Len(t, res.State.BuildQueue, 1)
+	require.Equal(t, "mill", res.State.BuildQueue[0].Name)
+	require.Empty(t, res.State.CraftQueue)
+
+	var child ActorResponse
+	require.Equal(t, http.StatusOK, do(t, server, http.MethodGet, "/kinds/timer/actors/"+res.Children[0].ID, &child))
+	require.Equal(t, &AddressResponse{Kind: actorpb.InventoryKind, ID: inventory.ID.String()}, child.Parent)
+	require.Nil(t, child.State)
+}
+
+func TestErrors(t *testing.T) {
+	_, server, inventory := newInventoryServer(t)
+	timer := model.Address{Kind: actor.TimerKind, ID: uuid.New()}
+
+	tests := []struct {
+		label          string
+		method         string
+		path           string
+		expectedStatus int
+	}{
+		{
+			label:          "unknown kind",
+			method:         http.MethodGet,
+			path:           "/kinds/castle/actors",
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			label:          "invalid id",
+			method:         http.MethodGet,
+			path:           "/kinds/inventory/actors/farm",
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			label:          "not active",
+			method:         http.MethodGet,
+			path:           "/kinds/inventory/actors/" + uuid.NewString(),
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			label:          "stop not active",
+			method:         http.MethodPost,
+			path:           "/kinds/inventory/actors/" + uuid.NewString() + "/stop",
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			label:          "passivate without factory",
+			method:         http.MethodPost,
+			path:           "/kinds/timer/actors/" + timer.ID.String() + "/passivate",
+			expectedStatus: http.StatusConflict,
+		},
+		{
+			label:          "wrong method",
+			method:         http.MethodDelete,
+			path:           "/kinds/inventory/actors/" + inventory.ID.String(),
+			expectedStatus: http.StatusMethodNotAllowed,
+		},
+	}
+
+	for _, tt := range tests {
+		tf := func(t *testing.T) {
+			require.Equal(t, tt.expectedStatus, do(t, server, tt.method, tt.path, nil))
+		}
+
+		t.Run(tt.label, tf)
+	}
+}
+
+func TestLifecycle(t *testing.T) {
+	kit, server, inventory := newInventoryServer(t)
+	path := "/kinds/inventory/actors/" + inventory.ID.String()
+
+	require.Equal(t, http.StatusNoContent, do(t, server, http.MethodPost, path+"/passivate", nil))
+	require.Equal(t, http.StatusNotFound, do(t, server, http.MethodGet, path, nil))
+	require.Equal(t, http.StatusNotFound, do(t, server, http.MethodPost, path+"/passivate", nil))
+
+	var timers ActorsResponse
+	require.Equal(t, http.StatusOK, do(t, server, http.MethodGet, "/kinds/timer/actors", &timers))
+	require.Zero(t, timers.Count)
+
+	probe := model.Address{Kind: "probe", ID: uuid.New()}
+	kit.Send(probe, wrapperspb.String("hello"))
+	require.Equal(t, http.StatusNoContent, do(t, server, http.MethodPost, "/kinds/probe/actors/"+probe.ID.String()+"/stop", nil))
+
+	var kinds []KindResponse
+	require.Equal(t, http.StatusOK, do(t, server, http.MethodGet, "/kinds", &kinds))
+	for _, kind := range kinds {
+		require.Zero(t, kind.Active, kind.Kind)
+		require.Equal(t, kind.Activations, kind.Passivations, kind.Kind)
+	}
+
+	_, err := kit.Manager.Actor(inventory)
+	require.NoError(t, err, "a passivated actor is activated again")
+	require.NoError(t, kit.Manager.Stop(context.Background(), inventory))
+}
@@ -0,0 +1,50 @@
+package clock
+
+import (
+	"context"
+	"time"
+
+	"github.com/gnarloqgames/ga-actor-poc/internal/model"
+	"github.com/gnarloqgames/ga-actor-poc/internal/timewheel"
+)
+
+// Clock is the source of time for actors, timers and the manager.
+type Clock interface {
+	Now() time.Time
+	// AfterFunc calls fn once d has elapsed on this clock.
+	AfterFunc(d time.Duration, fn func()) Timer
+}
+
+// Timer is a callback registered with a Clock.
+type Timer interface {
+	// Stop prevents the timer from firing. It reports false if the timer
+	// has already fired or been stopped.
+	Stop() bool
+	// Reschedule makes a pending timer fire after d, counting from now.
+	// It reports false if the timer has already fired or been stopped.
+	Reschedule(d time.Duration) bool
+	// Remaining returns how long until a pending timer fires.
+	Remaining() time.Duration
+}
+
+type realClock struct{}
+
+// Real tells wall-clock time and runs timers on the shared timing wheel.
+var Real Clock = realClock{}
+
+func (realClock) Now() time.Time {
+	return time.Now()
+}
+
+func (realClock) AfterFunc(d time.Duration, fn func()) Timer {
+	return timewheel.Default.AfterFunc(d, fn)
+}
+
+// FromContext returns the clock stored under model.KeyClock, or Real.
+func FromContext(ctx context.Context) Clock {
+	if c, ok := ctx.Value(model.KeyClock).(Clock); ok {
+		return c
+	}
+
+	return Real
+}
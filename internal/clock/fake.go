@@ -0,0 +1,164 @@
+package clock
+
+import (
+	"cmp"
+	"slices"
+	"sync"
+	"time"
+)
+
+// Fake is a Clock that only moves when told to. Timers fire synchronously,
+// in order of their fire time, from Advance and Set. A timer that is already
+// due when it is registered fires on the next call to either, so Advance(0)
+// flushes them.
+type Fake struct {
+	mx *sync.Mutex
+
+	now    time.Time
+	seq    uint64
+	timers []*fakeTimer
+}
+
+type fakeTimer struct {
+	f *Fake
+
+	at  time.Time
+	seq uint64
+	fn  func()
+}
+
+func NewFake(now time.Time) *Fake {
+	return &Fake{
+		mx: &sync.Mutex{},
+
+		now:    now,
+		timers: make([]*fakeTimer, 0),
+	}
+}
+
+func (f *Fake) Now() time.Time {
+	f.mx.Lock()
+	defer f.mx.Unlock()
+
+	return f.now
+}
+
+func (f *Fake) AfterFunc(d time.Duration, fn func()) Timer {
+	f.mx.Lock()
+	defer f.mx.Unlock()
+
+	t := &fakeTimer{
+		f:  f,
+		fn: fn,
+	}
+	f.schedule(t, d)
+
+	return t
+}
+
+// Len returns the number of pending timers.
+func (f *Fake) Len() int {
+	f.mx.Lock()
+	defer f.mx.Unlock()
+
+	return len(f.timers)
+}
+
+// Next returns the time the earliest pending timer fires at. It reports false
+// if no timer is pending.
+func (f *Fake) Next() (time.Time, bool) {
+	f.mx.Lock()
+	defer f.mx.Unlock()
+
+	if len(f.timers) == 0 {
+		return time.Time{}, false
+	}
+
+	return f.timers[0].at, true
+}
+
+// Advance moves the clock forward by d, firing every timer that becomes due.
+func (f *Fake) Advance(d time.Duration) {
+	f.Set(f.Now().Add(d))
+}
+
+// Set moves the clock to now, firing every timer due by then. The clock never
+// moves backwards, but timers already due still fire.
+func (f *Fake) Set(now time.Time) {
+	for {
+		f.mx.Lock()
+		if len(f.timers) == 0 || f.timers[0].at.After(now) {
+			if now.After(f.now) {
+				f.now = now
+			}
+			f.mx.Unlock()
+			return
+		}
+
+		t := f.timers[0]
+		f.timers = f.timers[1:]
+		if t.at.After(f.now) {
+			f.now = t.at
+		}
+		f.mx.Unlock()
+
+		t.fn()
+	}
+}
+
+// schedule inserts t in fire order. The caller must hold f.mx.
+func (f *Fake) schedule(t *fakeTimer, d time.Duration) {
+	f.seq++
+	t.seq = f.seq
+	t.at = f.now.Add(d)
+
+	i, _ := slices.BinarySearchFunc(f.timers, t, func(a, b *fakeTimer) int {
+		if c := a.at.Compare(b.at); c != 0 {
+			return c
+		}
+		return cmp.Compare(a.seq, b.seq)
+	})
+	f.timers = slices.Insert(f.timers, i, t)
+}
+
+// remove takes t out of the pending timers. The caller must hold f.mx.
+func (f *Fake) remove(t *fakeTimer) bool {
+	i := slices.Index(f.timers, t)
+	if i < 0 {
+		return false
+	}
+
+	f.timers = slices.Delete(f.timers, i, i+1)
+
+	return true
+}
+
+func (t *fakeTimer) Stop() bool {
+	t.f.mx.Lock()
+	defer t.f.mx.Unlock()
+
+	return t.f.remove(t)
+}
+
+func (t *fakeTimer) Reschedule(d time.Duration) bool {
+	t.f.mx.Lock()
+	defer t.f.mx.Unlock()
+
+	if !t.f.remove(t) {
+		return false
+	}
+	t.f.schedule(t, d)
+
+	return true
+}
+
+func (t *fakeTimer) Remaining() time.Duration {
+	t.f.mx.Lock()
+	defer t.f.mx.Unlock()
+
+	if slices.Index(t.f.timers, t) < 0 {
+		return 0
+	}
+
+	return max(t.at.Sub(t.f.now), 0)
+}
@@ -0,0 +1,102 @@
+package clock
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestFake(t *testing.T) {
+	start := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		label         string
+		delays        []time.Duration
+		advance       time.Duration
+		expectedFired []int
+	}{
+		{
+			label:         "fires in order",
+			delays:        []time.Duration{3 * time.Hour, time.Hour, 2 * time.Hour},
+			advance:       3 * time.Hour,
+			expectedFired: []int{1, 2, 0},
+		},
+		{
+			label:         "same time fires in registration order",
+			delays:        []time.Duration{time.Hour, time.Hour},
+			advance:       time.Hour,
+			expectedFired: []int{0, 1},
+		},
+		{
+			label:         "not yet due",
+			delays:        []time.Duration{time.Hour, 48 * time.Hour},
+			advance:       47 * time.Hour,
+			expectedFired: []int{0},
+		},
+		{
+			label:         "due on registration",
+			delays:        []time.Duration{0, -time.Minute},
+			advance:       0,
+			expectedFired: []int{1, 0},
+		},
+	}
+
+	for _, tt := range tests {
+		tf := func(t *testing.T) {
+			fake := NewFake(start)
+			fired := make([]int, 0)
+
+			for i, d := range tt.delays {
+				fake.AfterFunc(d, func() { fired = append(fired, i) })
+			}
+
+			fake.Advance(tt.advance)
+
+			require.Equal(t, tt.expectedFired, fired)
+			require.Equal(t, start.Add(tt.advance), fake.Now())
+			require.Equal(t, len(tt.delays)-len(tt.expectedFired), fake.Len())
+		}
+
+		t.Run(tt.label, tf)
+	}
+}
+
+func TestFakeTimer(t *testing.T) {
+	fake := NewFake(time.Now())
+	fired := 0
+	timer := fake.AfterFunc(time.Hour, func() { fired++ })
+
+	fake.Advance(20 * time.Minute)
+	require.Equal(t, 40*time.Minute, timer.Remaining())
+
+	require.True(t, timer.Reschedule(2*time.Hour))
+	fake.Advance(time.Hour)
+	require.Equal(t, 0, fired)
+	require.Equal(t, time.Hour, timer.Remaining())
+
+	require.True(t, timer.Stop())
+	require.False(t, timer.Stop())
+	require.False(t, timer.Reschedule(time.Minute))
+	require.Equal(t, time.Duration(0), timer.Remaining())
+
+	fake.Advance(24 * time.Hour)
+	require.Equal(t, 0, fired)
+}
+
+func TestFakeNestedTimers(t *testing.T) {
+	fake := NewFake(time.Now())
+	fired := 0
+
+	var tick func()
+	tick = func() {
+		fired++
+		fake.AfterFunc(time.Hour, tick)
+	}
+	fake.AfterFunc(time.Hour, tick)
+
+	fake.Advance(24 * time.Hour)
+
+	require.Equal(t, 24, fired)
+	require.Equal(t, 1, fake.Len())
+}
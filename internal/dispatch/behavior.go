@@ -0,0 +1,188 @@
+package dispatch
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+
+	"google.golang.org/protobuf/proto"
+)
+
+// DefaultStashSize bounds the messages a behavior stashes unless told
+// otherwise.
+const DefaultStashSize = 1000
+
+var ErrStashFull = errors.New("stash is full")
+
+// ReceiveFunc has the signature of model.Actor.Receive.
+type ReceiveFunc func(ctx context.Context, msg proto.Message, res proto.Message) error
+
+// Bind returns a ReceiveFunc dispatching to the handlers of r for a.
+func (r *Registry[A]) Bind(a A) ReceiveFunc {
+	return func(ctx context.Context, msg proto.Message, res proto.Message) error {
+		return r.Dispatch(a, ctx, msg, res)
+	}
+}
+
+type stashed struct {
+	ctx  context.Context
+	msg  proto.Message
+	res  proto.Message
+	done chan error
+}
+
+// Behavior switches the function an actor receives messages with at runtime.
+// Behaviors form a stack: Become replaces the top, BecomeStacked pushes onto
+// it and Unbecome pops back to the previous one. Messages the current
+// behavior cannot handle yet can be stashed and replayed, in order, once the
+// behavior changes.
+type Behavior struct {
+	mx        *sync.Mutex
+	behaviors []ReceiveFunc
+	stash     []*stashed
+	stashSize int
+}
+
+// NewBehavior creates a behavior receiving with initial, which Unbecome never
+// pops.
+func NewBehavior(initial ReceiveFunc) *Behavior {
+	return &Behavior{
+		mx:        &sync.Mutex{},
+		behaviors: []ReceiveFunc{initial},
+		stash:     make([]*stashed, 0),
+		stashSize: DefaultStashSize,
+	}
+}
+
+// Receive hands msg to the current behavior. An actor calls it from its own
+// Receive.
+func (b *Behavior) Receive(ctx context.Context, msg proto.Message, res proto.Message) error {
+	b.mx.Lock()
+	current := b.behaviors[len(b.behaviors)-1]
+	b.mx.Unlock()
+
+	return current(ctx, msg, res)
+}
+
+// Become replaces the current behavior with fn.
+func (b *Behavior) Become(fn ReceiveFunc) {
+	b.mx.Lock()
+	defer b.mx.Unlock()
+
+	b.behaviors[len(b.behaviors)-1] = fn
+}
+
+// BecomeStacked makes fn the current behavior, keeping the previous one for
+// Unbecome.
+func (b *Behavior) BecomeStacked(fn ReceiveFunc) {
+	b.mx.Lock()
+	defer b.mx.Unlock()
+
+	b.behaviors = append(b.behaviors, fn)
+}
+
+// Unbecome goes back to the behavior current before the last BecomeStacked.
+// It reports false, keeping the initial behavior, if there is none.
+func (b *Behavior) Unbecome() bool {
+	b.mx.Lock()
+	defer b.mx.Unlock()
+
+	if len(b.behaviors) == 1 {
+		return false
+	}
+
+	b.behaviors = b.behaviors[:len(b.behaviors)-1]
+
+	return true
+}
+
+// SetStashSize changes how many messages can be stashed at once.
+func (b *Behavior) SetStashSize(size int) {
+	b.mx.Lock()
+	defer b.mx.Unlock()
+
+	b.stashSize = size
+}
+
+// StashLen returns the number of stashed messages.
+func (b *Behavior) StashLen() int {
+	b.mx.Lock()
+	defer b.mx.Unlock()
+
+	return len(b.stash)
+}
+
+// Stash keeps msg to be received again by Unstash. A behavior calls it with
+// the arguments of its Receive and returns its result. Messages sent without
+// expecting a response are accepted right away. When the sender expects a
+// response in res, Stash waits until the message is unstashed and returns the
+// result of handling it, or until ctx is done.
+func (b *Behavior) Stash(ctx context.Context, msg proto.Message, res proto.Message) error {
+	entry := &stashed{
+		ctx: context.WithoutCancel(ctx),
+		msg: msg,
+		res: res,
+	}
+	if res != nil {
+		entry.ctx = ctx
+		entry.done = make(chan error, 1)
+	}
+
+	b.mx.Lock()
+	if len(b.stash) >= b.stashSize {
+		b.mx.Unlock()
+
+		return fmt.Errorf("%w: %d messages", ErrStashFull, b.stashSize)
+	}
+	b.stash = append(b.stash, entry)
+	b.mx.Unlock()
+
+	if entry.done == nil {
+		return nil
+	}
+
+	select {
+	case err := <-entry.done:
+		return err
+	case <-ctx.Done():
+	}
+
+	// Unstash may have taken the message already, in which case res is
+	// being filled and the result is on its way.
+	b.mx.Lock()
+	i := slices.Index(b.stash, entry)
+	if i >= 0 {
+		b.stash = slices.Delete(b.stash, i, i+1)
+	}
+	b.mx.Unlock()
+
+	if i < 0 {
+		return <-entry.done
+	}
+
+	return ctx.Err()
+}
+
+// Unstash receives every stashed message again with the current behavior, in
+// the order they were stashed. Messages stashed again while unstashing wait
+// for the next call.
+func (b *Behavior) Unstash() {
+	b.mx.Lock()
+	stash := b.stash
+	b.stash = make([]*stashed, 0)
+	b.mx.Unlock()
+
+	for _, entry := range stash {
+		if entry.done != nil && entry.ctx.Err() != nil {
+			entry.done <- entry.ctx.Err()
+			continue
+		}
+
+		err := b.Receive(entry.ctx, entry.msg, entry.res)
+		if entry.done != nil {
+			entry.done <- err
+		}
+	}
+}
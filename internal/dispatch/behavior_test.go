@@ -0,0 +1,139 @@
+package dispatch
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/gnarloqgames/ga-actor-poc/message"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+// lockable handles builds with a registry while unlocked and stashes them
+// while locked.
+type lockable struct {
+	counter
+	behavior *Behavior
+}
+
+func newLockable() *lockable {
+	l := &lockable{}
+	l.behavior = NewBehavior(newTestRegistry().Bind(&l.counter))
+
+	return l
+}
+
+func (l *lockable) lock() {
+	l.behavior.BecomeStacked(l.behavior.Stash)
+}
+
+func (l *lockable) unlock() {
+	l.behavior.Unbecome()
+	l.behavior.Unstash()
+}
+
+func TestBehavior(t *testing.T) {
+	l := newLockable()
+	var order []string
+	l.behavior.Become(func(ctx context.Context, msg proto.Message, res proto.Message) error {
+		order = append(order, msg.(*message.BuildRequest).TraceID)
+		return nil
+	})
+
+	l.lock()
+	for _, trace := range []string{"first", "second", "third"} {
+		require.NoError(t, l.behavior.Receive(context.Background(), &message.BuildRequest{TraceID: trace}, nil))
+	}
+	require.Equal(t, 3, l.behavior.StashLen())
+	require.Empty(t, order)
+
+	l.unlock()
+	require.Equal(t, []string{"first", "second", "third"}, order)
+	require.Zero(t, l.behavior.StashLen())
+
+	require.False(t, l.behavior.Unbecome())
+}
+
+func TestBehaviorStashedAsk(t *testing.T) {
+	l := newLockable()
+	l.lock()
+
+	res := &message.BuildResponse{}
+	done := make(chan error, 1)
+	go func() {
+		done <- l.behavior.Receive(context.Background(), &message.BuildRequest{TraceID: "trace"}, res)
+	}()
+
+	require.Eventually(t, func() bool { return l.behavior.StashLen() == 1 }, time.Second, time.Millisecond)
+	l.unlock()
+
+	require.NoError(t, <-done)
+	require.Equal(t, "trace", res.TraceID)
+	require.Equal(t, 1, l.builds)
+}
+
+func TestBehaviorStashErrors(t *testing.T) {
+	tests := []struct {
+		label         string
+		size          int
+		ctx           func() (context.Context, context.CancelFunc)
+		res           proto.Message
+		expectedError error
+	}{
+		{
+			label:         "full",
+			size:          0,
+			ctx:           func() (context.Context, context.CancelFunc) { return context.WithCancel(context.Background()) },
+			expectedError: ErrStashFull,
+		},
+		{
+			label: "ask timeout",
+			size:  1,
+			ctx: func() (context.Context, context.CancelFunc) {
+				return context.WithTimeout(context.Background(), time.Millisecond)
+			},
+			res:           &message.BuildResponse{},
+			expectedError: context.DeadlineExceeded,
+		},
+	}
+
+	for _, tt := range tests {
+		tf := func(t *testing.T) {
+			l := newLockable()
+			l.behavior.SetStashSize(tt.size)
+			l.lock()
+
+			ctx, cancel := tt.ctx()
+			defer cancel()
+
+			err := l.behavior.Receive(ctx, &message.BuildRequest{}, tt.res)
+			require.ErrorIs(t, err, tt.expectedError)
+			require.Zero(t, l.behavior.StashLen())
+
+			l.unlock()
+			require.Zero(t, l.builds)
+		}
+
+		t.Run(tt.label, tf)
+	}
+}
+
+func TestBehaviorUnhandledWhileStacked(t *testing.T) {
+	l := newLockable()
+	l.behavior.BecomeStacked(func(ctx context.Context, msg proto.Message, res proto.Message) error {
+		if _, ok := msg.(*wrapperspb.StringValue); ok {
+			return nil
+		}
+
+		return l.behavior.Stash(ctx, msg, res)
+	})
+
+	require.NoError(t, l.behavior.Receive(context.Background(), wrapperspb.String("handled"), nil))
+	require.NoError(t, l.behavior.Receive(context.Background(), &message.BuildRequest{}, nil))
+	require.Equal(t, 1, l.behavior.StashLen())
+
+	l.unlock()
+	require.Equal(t, 1, l.builds)
+}
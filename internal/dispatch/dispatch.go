@@ -0,0 +1,139 @@
+package dispatch
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"slices"
+	"sync"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+const (
+	AttributeKind    string = "actor_kind"
+	AttributeMessage string = "message"
+)
+
+var (
+	ErrUnhandled        = errors.New("unhandled message")
+	ErrInvalidResponse  = errors.New("invalid response type")
+	ErrDuplicateHandler = errors.New("handler already registered")
+)
+
+// HandlerFunc handles one message type for actor a. res is nil when the
+// sender does not expect a response.
+type HandlerFunc[A any] func(a A, ctx context.Context, msg proto.Message, res proto.Message) error
+
+// Registry maps the proto full names of the messages an actor kind accepts to
+// their handlers. A registry is shared by every actor of its kind and is
+// usually filled once, when the package defining the kind is initialized.
+type Registry[A any] struct {
+	kind string
+
+	mx       *sync.RWMutex
+	handlers map[protoreflect.FullName]HandlerFunc[A]
+}
+
+func NewRegistry[A any](kind string) *Registry[A] {
+	return &Registry[A]{
+		kind: kind,
+
+		mx:       &sync.RWMutex{},
+		handlers: make(map[protoreflect.FullName]HandlerFunc[A]),
+	}
+}
+
+// Register adds the handler for messages named name.
+func (r *Registry[A]) Register(name protoreflect.FullName, handler HandlerFunc[A]) error {
+	r.mx.Lock()
+	defer r.mx.Unlock()
+
+	if _, ok := r.handlers[name]; ok {
+		return fmt.Errorf("%w: %s for %s", ErrDuplicateHandler, name, r.kind)
+	}
+
+	r.handlers[name] = handler
+
+	return nil
+}
+
+// Handles reports whether a handler is registered for messages named name.
+func (r *Registry[A]) Handles(name protoreflect.FullName) bool {
+	r.mx.RLock()
+	defer r.mx.RUnlock()
+
+	_, ok := r.handlers[name]
+
+	return ok
+}
+
+// Names returns the names of every handled message in lexical order.
+func (r *Registry[A]) Names() []protoreflect.FullName {
+	r.mx.RLock()
+	defer r.mx.RUnlock()
+
+	names := make([]protoreflect.FullName, 0, len(r.handlers))
+	for name := range r.handlers {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+
+	return names
+}
+
+// Dispatch calls the handler registered for msg. Messages without a handler
+// are logged and rejected with ErrUnhandled.
+func (r *Registry[A]) Dispatch(a A, ctx context.Context, msg proto.Message, res proto.Message) error {
+	if msg == nil {
+		return fmt.Errorf("%w: nil message for %s", ErrUnhandled, r.kind)
+	}
+
+	name := msg.ProtoReflect().Descriptor().FullName()
+
+	r.mx.RLock()
+	handler, ok := r.handlers[name]
+	r.mx.RUnlock()
+
+	if !ok {
+		slog.Warn("unhandled message",
+			AttributeKind, r.kind,
+			AttributeMessage, string(name),
+		)
+
+		return fmt.Errorf("%w: %s for %s", ErrUnhandled, name, r.kind)
+	}
+
+	return handler(a, ctx, msg, res)
+}
+
+// Handle registers fn for messages of type Req. Responses of any type other
+// than Res are rejected with ErrInvalidResponse before fn runs, and fn is given
+// a nil Res when the sender expects no response. Handle panics if Req already
+// has a handler, as registration happens while initializing the actor kind.
+func Handle[A any, Req proto.Message, Res proto.Message](r *Registry[A], fn func(a A, ctx context.Context, req Req, res Res) error) {
+	var zero Req
+	name := zero.ProtoReflect().Descriptor().FullName()
+
+	err := r.Register(name, func(a A, ctx context.Context, msg proto.Message, res proto.Message) error {
+		var typed Res
+		if res != nil {
+			var ok bool
+			if typed, ok = res.(Res); !ok {
+				return fmt.Errorf("%w: %s expects %T, got %T", ErrInvalidResponse, name, typed, res)
+			}
+		}
+
+		req, ok := msg.(Req)
+		if !ok {
+			return fmt.Errorf("%w: %s as %T", ErrUnhandled, name, msg)
+		}
+
+		return fn(a, ctx, req, typed)
+	})
+	if err != nil {
+		panic(err)
+	}
+}
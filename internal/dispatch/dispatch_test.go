@@ -0,0 +1,106 @@
+package dispatch
+
+import (
+	"context"
+	"testing"
+
+	"github.com/gnarloqgames/ga-actor-poc/message"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+type counter struct {
+	builds int
+}
+
+func newTestRegistry() *Registry[*counter] {
+	r := NewRegistry[*counter]("counter")
+
+	Handle(r, func(c *counter, ctx context.Context, req *message.BuildRequest, res *message.BuildResponse) error {
+		c.builds++
+		if res != nil {
+			res.TraceID = req.TraceID
+		}
+
+		return nil
+	})
+
+	return r
+}
+
+func TestDispatch(t *testing.T) {
+	tests := []struct {
+		label          string
+		msg            proto.Message
+		res            proto.Message
+		expectedError  error
+		expectedBuilds int
+		expectedTrace  string
+	}{
+		{
+			label:          "with response",
+			msg:            &message.BuildRequest{TraceID: "trace"},
+			res:            &message.BuildResponse{},
+			expectedBuilds: 1,
+			expectedTrace:  "trace",
+		},
+		{
+			label:          "without response",
+			msg:            &message.BuildRequest{TraceID: "trace"},
+			expectedBuilds: 1,
+		},
+		{
+			label:          "unhandled",
+			msg:            wrapperspb.String("unknown"),
+			expectedError:  ErrUnhandled,
+			expectedBuilds: 0,
+		},
+		{
+			label:          "nil message",
+			expectedError:  ErrUnhandled,
+			expectedBuilds: 0,
+		},
+		{
+			label:          "invalid response",
+			msg:            &message.BuildRequest{TraceID: "trace"},
+			res:            &message.ModifierResponse{},
+			expectedError:  ErrInvalidResponse,
+			expectedBuilds: 0,
+		},
+	}
+
+	for _, tt := range tests {
+		tf := func(t *testing.T) {
+			r := newTestRegistry()
+			c := &counter{}
+
+			err := r.Dispatch(c, context.Background(), tt.msg, tt.res)
+
+			require.ErrorIs(t, err, tt.expectedError)
+			require.Equal(t, tt.expectedBuilds, c.builds)
+			if res, ok := tt.res.(*message.BuildResponse); ok {
+				require.Equal(t, tt.expectedTrace, res.TraceID)
+			}
+		}
+
+		t.Run(tt.label, tf)
+	}
+}
+
+func TestRegister(t *testing.T) {
+	r := newTestRegistry()
+
+	require.True(t, r.Handles("message.BuildRequest"))
+	require.False(t, r.Handles("message.CraftRequest"))
+	require.Equal(t, []protoreflect.FullName{"message.BuildRequest"}, r.Names())
+
+	err := r.Register("message.BuildRequest", nil)
+	require.ErrorIs(t, err, ErrDuplicateHandler)
+	require.Panics(t, func() {
+		Handle(r, func(c *counter, ctx context.Context, req *message.BuildRequest, res *message.BuildResponse) error {
+			return nil
+		})
+	})
+}
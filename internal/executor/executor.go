@@ -0,0 +1,33 @@
+package executor
+
+import (
+	"context"
+
+	"github.com/gnarloqgames/ga-actor-poc/internal/model"
+)
+
+// Executor runs the work actors schedule outside of Receive, such as timer
+// callbacks and queued builds.
+type Executor interface {
+	// Go runs fn without waiting for it to return. It must never call fn
+	// from the calling goroutine before returning.
+	Go(fn func())
+}
+
+type goroutines struct{}
+
+// Default runs every function in its own goroutine.
+var Default Executor = goroutines{}
+
+func (goroutines) Go(fn func()) {
+	go fn()
+}
+
+// FromContext returns the executor stored under model.KeyExecutor, or Default.
+func FromContext(ctx context.Context) Executor {
+	if e, ok := ctx.Value(model.KeyExecutor).(Executor); ok {
+		return e
+	}
+
+	return Default
+}
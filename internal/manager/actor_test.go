@@ -0,0 +1,62 @@
+package manager
+
+import (
+	"context"
+	"testing"
+
+	"github.com/gnarloqgames/ga-actor-poc/internal/model"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+)
+
+// testActor is the actor of the manager tests. Tests set the hooks they need
+// and leave the others nil, which do nothing.
+type testActor struct {
+	id   uuid.UUID
+	kind string
+
+	receive func(ctx context.Context, msg proto.Message, res proto.Message) error
+	destroy func(address model.Address)
+}
+
+func (a *testActor) GetID() uuid.UUID          { return a.id }
+func (a *testActor) GetKind() string           { return a.kind }
+func (a *testActor) Start(ctx context.Context) {}
+
+func (a *testActor) Destroy(ctx context.Context) {
+	if a.destroy != nil {
+		a.destroy(model.Address{Kind: a.kind, ID: a.id})
+	}
+}
+
+func (a *testActor) Receive(ctx context.Context, msg proto.Message, res proto.Message) error {
+	if a.receive == nil {
+		return nil
+	}
+
+	return a.receive(ctx, msg, res)
+}
+
+// newTestKind registers kind on m. Its actors are copies of template with the
+// kind and the ID they are activated with.
+func newTestKind(t *testing.T, m *Manager, kind string, template testActor) {
+	t.Helper()
+
+	err := m.NewKind(kind, func(ctx context.Context) model.Actor {
+		a := template
+		a.id = selfID(ctx)
+		a.kind = kind
+
+		return &a
+	})
+	require.NoError(t, err)
+}
+
+// selfID returns the ID of the actor a factory is creating or a message is
+// received by.
+func selfID(ctx context.Context) uuid.UUID {
+	actx, _ := model.ActorContextFromContext(ctx)
+
+	return actx.Self().ID
+}
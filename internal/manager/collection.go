@@ -1,39 +1,265 @@
 package manager
 
 import (
+	"cmp"
+	"container/list"
 	"context"
 	"sync"
+	"sync/atomic"
+	"time"
 
+	"github.com/gnarloqgames/ga-actor-poc/internal/clock"
+	"github.com/gnarloqgames/ga-actor-poc/internal/executor"
 	"github.com/gnarloqgames/ga-actor-poc/internal/model"
 	"github.com/google/uuid"
 )
 
+// MaxTerminationReasons bounds the number of actors of a kind whose termination
+// reason is kept for watchers that start watching them after they stopped. The
+// reasons of the actors terminated longest ago are forgotten first, and their
+// late watchers are told ReasonNotActive.
+const MaxTerminationReasons = 10000
+
 type ActorCollection struct {
 	mx *sync.Mutex
 
-	actors  map[uuid.UUID]model.Actor
-	factory actorFactory
+	actors   map[uuid.UUID]model.Actor
+	activity map[uuid.UUID]*activity
+	// reasons holds why actors that are not running were last terminated,
+	// for at most maxReasons actors, or MaxTerminationReasons if unset.
+	// terminations orders them from the most recently terminated.
+	reasons      map[uuid.UUID]*list.Element
+	terminations *list.List
+	maxReasons   int
+	factory      actorFactory
+	manager      *Manager
+	clock        clock.Clock
+	executor     executor.Executor
+
+	activations  atomic.Uint64
+	passivations atomic.Uint64
+	inFlight     atomic.Int64
 }
 
 func NewActorCollection(factoryFn actorFactory) *ActorCollection {
 	return &ActorCollection{
 		mx: &sync.Mutex{},
 
-		actors:  make(map[uuid.UUID]model.Actor),
-		factory: factoryFn,
+		actors:       make(map[uuid.UUID]model.Actor),
+		activity:     make(map[uuid.UUID]*activity),
+		reasons:      make(map[uuid.UUID]*list.Element),
+		terminations: list.New(),
+		factory:      factoryFn,
 	}
 }
 
+// Get returns the actor at address, creating it if it is not running. It
+// returns nil for collections of kinds only made of spawned actors.
 func (i *ActorCollection) Get(address model.Address) model.Actor {
 	i.mx.Lock()
 	defer i.mx.Unlock()
 
 	inv, ok := i.actors[address.ID]
 	if !ok {
-		ctx := context.WithValue(context.Background(), model.KeyID, address.ID)
+		if i.factory == nil {
+			return nil
+		}
+
+		ctx := context.Background()
+		if i.manager != nil {
+			ctx = model.WithActorContext(ctx, i.manager.Context(address))
+		}
+		if i.clock != nil {
+			ctx = context.WithValue(ctx, model.KeyClock, i.clock)
+		}
+		if i.executor != nil {
+			ctx = context.WithValue(ctx, model.KeyExecutor, i.executor)
+		}
 		inv = i.factory(ctx)
 		i.actors[address.ID] = inv
+		i.activated(address.ID)
 	}
 
 	return inv
 }
+
+// Lookup returns the actor at address without activating it.
+func (i *ActorCollection) Lookup(address model.Address) (model.Actor, bool) {
+	i.mx.Lock()
+	defer i.mx.Unlock()
+
+	actor, ok := i.actors[address.ID]
+
+	return actor, ok
+}
+
+// Put adds an actor created outside of the collection. It reports false if an
+// actor with the same ID is already running.
+func (i *ActorCollection) Put(actor model.Actor) bool {
+	i.mx.Lock()
+	defer i.mx.Unlock()
+
+	if _, ok := i.actors[actor.GetID()]; ok {
+		return false
+	}
+
+	i.actors[actor.GetID()] = actor
+	i.activated(actor.GetID())
+
+	return true
+}
+
+// Remove takes the actor at address out of the collection, as stopped.
+func (i *ActorCollection) Remove(address model.Address) (model.Actor, bool) {
+	return i.terminate(address, ReasonStopped)
+}
+
+// terminate takes the actor at address out of the collection and records
+// reason as the reason it was last terminated for.
+func (i *ActorCollection) terminate(address model.Address, reason string) (model.Actor, bool) {
+	i.mx.Lock()
+	defer i.mx.Unlock()
+
+	actor, ok := i.actors[address.ID]
+	if !ok {
+		return nil, false
+	}
+
+	delete(i.actors, address.ID)
+	delete(i.activity, address.ID)
+	i.passivations.Add(1)
+	i.remember(address.ID, reason)
+
+	return actor, true
+}
+
+// termination is why an actor was last terminated.
+type termination struct {
+	id     uuid.UUID
+	reason string
+}
+
+// remember records reason as the reason the actor with id was last terminated
+// for, forgetting the oldest reasons past maxReasons. The caller must hold
+// i.mx.
+func (i *ActorCollection) remember(id uuid.UUID, reason string) {
+	if i.reasons == nil {
+		i.reasons = make(map[uuid.UUID]*list.Element)
+		i.terminations = list.New()
+	}
+
+	i.forget(id)
+	i.reasons[id] = i.terminations.PushFront(termination{id: id, reason: reason})
+
+	for len(i.reasons) > cmp.Or(i.maxReasons, MaxTerminationReasons) {
+		i.forget(i.terminations.Back().Value.(termination).id)
+	}
+}
+
+// forget drops the reason the actor with id was last terminated for. The
+// caller must hold i.mx.
+func (i *ActorCollection) forget(id uuid.UUID) {
+	if element, ok := i.reasons[id]; ok {
+		i.terminations.Remove(element)
+		delete(i.reasons, id)
+	}
+}
+
+// reason returns why the actor at address is not running: the reason it was
+// last terminated for, or ReasonNotActive if it never ran.
+func (i *ActorCollection) reason(address model.Address) (string, bool) {
+	i.mx.Lock()
+	defer i.mx.Unlock()
+
+	if _, ok := i.actors[address.ID]; ok {
+		return "", false
+	}
+
+	if element, ok := i.reasons[address.ID]; ok {
+		return element.Value.(termination).reason, true
+	}
+
+	return ReasonNotActive, true
+}
+
+// activity tracks what a running actor is doing.
+type activity struct {
+	inFlight int64
+	last     time.Time
+}
+
+// activated starts tracking the activity of the actor with id. The caller
+// must hold i.mx.
+func (i *ActorCollection) activated(id uuid.UUID) {
+	if i.activity == nil {
+		i.activity = make(map[uuid.UUID]*activity)
+	}
+
+	i.activity[id] = &activity{last: i.now()}
+	i.forget(id)
+	i.activations.Add(1)
+}
+
+// begin records that the actor at address is handed a message.
+func (i *ActorCollection) begin(address model.Address) {
+	i.inFlight.Add(1)
+
+	i.mx.Lock()
+	defer i.mx.Unlock()
+
+	if a, ok := i.activity[address.ID]; ok {
+		a.inFlight++
+		a.last = i.now()
+	}
+}
+
+// end records that the actor at address is done with a message. Actors
+// removed in the meantime are no longer tracked.
+func (i *ActorCollection) end(address model.Address) {
+	i.inFlight.Add(-1)
+
+	i.mx.Lock()
+	defer i.mx.Unlock()
+
+	if a, ok := i.activity[address.ID]; ok {
+		a.inFlight--
+		a.last = i.now()
+	}
+}
+
+func (i *ActorCollection) now() time.Time {
+	if i.clock == nil {
+		return time.Now()
+	}
+
+	return i.clock.Now()
+}
+
+// hasFactory reports whether the collection can activate its actors.
+func (i *ActorCollection) hasFactory() bool {
+	i.mx.Lock()
+	defer i.mx.Unlock()
+
+	return i.factory != nil
+}
+
+// Len returns the number of running actors.
+func (i *ActorCollection) Len() int {
+	i.mx.Lock()
+	defer i.mx.Unlock()
+
+	return len(i.actors)
+}
+
+// Actors returns the running actors.
+func (i *ActorCollection) Actors() []model.Actor {
+	i.mx.Lock()
+	defer i.mx.Unlock()
+
+	actors := make([]model.Actor, 0, len(i.actors))
+	for _, actor := range i.actors {
+		actors = append(actors, actor)
+	}
+
+	return actors
+}
@@ -0,0 +1,144 @@
+package manager
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"slices"
+	"time"
+
+	"github.com/gnarloqgames/ga-actor-poc/internal/model"
+	"github.com/gnarloqgames/ga-actor-poc/message"
+	"github.com/google/uuid"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+var _ model.ActorContext = (*actorContext)(nil)
+
+type actorContext struct {
+	manager  *Manager
+	self     model.Address
+	envelope *message.Envelope
+}
+
+// Context returns the actor context of the actor at address, outside of any
+// message. Factories receive it through their context.
+func (m *Manager) Context(address model.Address) model.ActorContext {
+	return &actorContext{manager: m, self: address}
+}
+
+func (c *actorContext) Self() model.Address {
+	return c.self
+}
+
+func (c *actorContext) Sender() (model.Address, bool) {
+	if c.envelope.GetSender() == nil {
+		return model.Address{}, false
+	}
+
+	sender, err := model.AddressFromMessage(c.envelope.GetSender())
+	if err != nil {
+		return model.Address{}, false
+	}
+
+	return sender, true
+}
+
+func (c *actorContext) Logger() *slog.Logger {
+	logger := slog.With(
+		"actor_kind", c.self.Kind,
+		"actor_id", c.self.ID,
+	)
+	if c.envelope != nil {
+		logger = logger.With("correlation_id", c.envelope.CorrelationID)
+	}
+
+	return logger
+}
+
+func (c *actorContext) Send(ctx context.Context, address model.Address, msg proto.Message, timeout time.Duration, opts ...model.SendOption) error {
+	return c.manager.Send(c.bind(ctx), address, msg, timeout, c.options(opts)...)
+}
+
+func (c *actorContext) Ask(ctx context.Context, address model.Address, msg proto.Message, res proto.Message, timeout time.Duration, opts ...model.SendOption) error {
+	return c.manager.Ask(c.bind(ctx), address, msg, res, timeout, c.options(opts)...)
+}
+
+func (c *actorContext) Tell(ctx context.Context, msg proto.Message, timeout time.Duration) error {
+	return c.Send(ctx, c.self, msg, timeout)
+}
+
+func (c *actorContext) After(d time.Duration, msg proto.Message) (uuid.UUID, error) {
+	return c.manager.scheduleAt(c.self, c.manager.clock.Now().Add(d), msg, c.envelope.GetHeaders())
+}
+
+func (c *actorContext) CancelTimer(id uuid.UUID) error {
+	return c.manager.CancelTimer(id)
+}
+
+func (c *actorContext) SaveState(state proto.Message) error {
+	return c.manager.SaveState(c.self, state)
+}
+
+func (c *actorContext) LoadState(state proto.Message) (bool, error) {
+	return c.manager.LoadState(c.self, state)
+}
+
+func (c *actorContext) Parent() (model.Address, bool) {
+	return c.manager.Parent(c.self)
+}
+
+func (c *actorContext) Children() []model.Address {
+	return c.manager.Children(c.self)
+}
+
+func (c *actorContext) Spawn(kind string) (model.Address, error) {
+	return c.manager.Spawn(c.self, kind)
+}
+
+func (c *actorContext) SpawnActor(child model.Actor) (model.Address, error) {
+	return c.manager.SpawnActor(c.self, child)
+}
+
+func (c *actorContext) Stop(ctx context.Context, address model.Address) error {
+	if address != c.self && !slices.Contains(c.Children(), address) {
+		return fmt.Errorf("%w: %s/%s is not a child of %s/%s", model.ErrNotActive, address.Kind, address.ID, c.self.Kind, c.self.ID)
+	}
+
+	return c.manager.Stop(ctx, address)
+}
+
+func (c *actorContext) Watch(target model.Address) error {
+	return c.manager.Watch(c.self, target)
+}
+
+func (c *actorContext) Unwatch(target model.Address) {
+	c.manager.Unwatch(c.self, target)
+}
+
+func (c *actorContext) Publish(ctx context.Context, topic string, event proto.Message) {
+	c.manager.Publish(model.WithActorContext(ctx, c), topic, event)
+}
+
+func (c *actorContext) Subscribe(topic string, eventType protoreflect.FullName) uuid.UUID {
+	return c.manager.Subscribe(Filter{Topic: topic, Type: eventType}, c.self)
+}
+
+func (c *actorContext) Unsubscribe(id uuid.UUID) bool {
+	return c.manager.Unsubscribe(id)
+}
+
+// bind carries the headers of the message being received over to messages
+// sent while handling it, even when ctx is not the context of Receive.
+func (c *actorContext) bind(ctx context.Context) context.Context {
+	if _, ok := model.EnvelopeFromContext(ctx); ok || c.envelope == nil {
+		return ctx
+	}
+
+	return model.WithEnvelope(ctx, c.envelope)
+}
+
+func (c *actorContext) options(opts []model.SendOption) []model.SendOption {
+	return append([]model.SendOption{model.WithSender(c.self)}, opts...)
+}
@@ -0,0 +1,186 @@
+package manager
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/gnarloqgames/ga-actor-poc/internal/clock"
+	"github.com/gnarloqgames/ga-actor-poc/internal/model"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+type received struct {
+	self      model.Address
+	sender    model.Address
+	hasSender bool
+	msg       string
+}
+
+// runCommand runs the command found in the messages it receives through the
+// actor context and records every message with its sender.
+func runCommand(peer model.Address, ch chan received) func(ctx context.Context, msg proto.Message, res proto.Message) error {
+	return func(ctx context.Context, msg proto.Message, res proto.Message) error {
+		actx, ok := model.ActorContextFromContext(ctx)
+		if !ok {
+			return nil
+		}
+
+		sender, hasSender := actx.Sender()
+		value := msg.(*wrapperspb.StringValue).Value
+		ch <- received{self: actx.Self(), sender: sender, hasSender: hasSender, msg: value}
+
+		switch value {
+		case "send":
+			return actx.Send(ctx, peer, wrapperspb.String("from peer"), time.Second)
+		case "tell":
+			return actx.Tell(ctx, wrapperspb.String("to self"), time.Second)
+		case "after":
+			_, err := actx.After(time.Hour, wrapperspb.String("later"))
+			return err
+		case "cancel":
+			id, err := actx.After(time.Hour, wrapperspb.String("never"))
+			if err != nil {
+				return err
+			}
+			return actx.CancelTimer(id)
+		case "ask":
+			reply := &wrapperspb.StringValue{}
+			if err := actx.Ask(ctx, peer, wrapperspb.String("question"), reply, time.Second); err != nil {
+				return err
+			}
+			if res != nil {
+				res.(*wrapperspb.StringValue).Value = reply.Value
+			}
+		case "question":
+			if res != nil {
+				res.(*wrapperspb.StringValue).Value = "answer from " + actx.Self().ID.String()
+			}
+		}
+
+		return nil
+	}
+}
+
+func newContextManager(t *testing.T, peer model.Address) (*Manager, *clock.Fake, chan received) {
+	t.Helper()
+
+	fake := clock.NewFake(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	manager := NewManagerWithClock(fake)
+	ch := make(chan received, 10)
+	newTestKind(t, manager, "context", testActor{receive: runCommand(peer, ch)})
+
+	return manager, fake, ch
+}
+
+func TestActorContext(t *testing.T) {
+	self := model.Address{Kind: "context", ID: uuid.New()}
+	peer := model.Address{Kind: "context", ID: uuid.New()}
+
+	tests := []struct {
+		label    string
+		command  string
+		advance  time.Duration
+		expected []received
+	}{
+		{
+			label:   "external",
+			command: "noop",
+			expected: []received{
+				{self: self, msg: "noop"},
+			},
+		},
+		{
+			label:   "send",
+			command: "send",
+			expected: []received{
+				{self: self, msg: "send"},
+				{self: peer, sender: self, hasSender: true, msg: "from peer"},
+			},
+		},
+		{
+			label:   "tell",
+			command: "tell",
+			expected: []received{
+				{self: self, msg: "tell"},
+				{self: self, sender: self, hasSender: true, msg: "to self"},
+			},
+		},
+		{
+			label:   "after",
+			command: "after",
+			advance: time.Hour,
+			expected: []received{
+				{self: self, msg: "after"},
+				{self: self, msg: "later"},
+			},
+		},
+		{
+			label:   "cancel",
+			command: "cancel",
+			advance: time.Hour,
+			expected: []received{
+				{self: self, msg: "cancel"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		tf := func(t *testing.T) {
+			manager, fake, ch := newContextManager(t, peer)
+
+			err := manager.Send(context.Background(), self, wrapperspb.String(tt.command), time.Second)
+			require.NoError(t, err)
+			fake.Advance(tt.advance)
+
+			for _, expected := range tt.expected {
+				select {
+				case r := <-ch:
+					require.Equal(t, expected, r)
+				case <-time.After(time.Second):
+					t.Fatalf("%s was not received", expected.msg)
+				}
+			}
+
+			select {
+			case r := <-ch:
+				t.Fatalf("unexpected message %s", r.msg)
+			default:
+			}
+		}
+
+		t.Run(tt.label, tf)
+	}
+}
+
+func TestActorContextAsk(t *testing.T) {
+	self := model.Address{Kind: "context", ID: uuid.New()}
+	peer := model.Address{Kind: "context", ID: uuid.New()}
+	manager, _, _ := newContextManager(t, peer)
+
+	res := &wrapperspb.StringValue{}
+	err := manager.Ask(context.Background(), self, wrapperspb.String("ask"), res, time.Second)
+	require.NoError(t, err)
+	require.Equal(t, "answer from "+peer.ID.String(), res.Value)
+}
+
+func TestActorContextHeaders(t *testing.T) {
+	manager := NewManager()
+	self := model.Address{Kind: "context", ID: uuid.New()}
+	peer := model.Address{Kind: "context", ID: uuid.New()}
+
+	actx := &actorContext{manager: manager, self: self}
+	ctx := model.WithEnvelope(context.Background(), manager.envelope(context.Background(), self, time.Second, []model.SendOption{model.WithHeader("trace", "abc")}))
+	actx.envelope, _ = model.EnvelopeFromContext(ctx)
+
+	envelope, ok := model.EnvelopeFromContext(actx.bind(context.Background()))
+	require.True(t, ok)
+	require.Equal(t, "abc", envelope.Headers["trace"])
+
+	outgoing := manager.envelope(actx.bind(context.Background()), peer, time.Second, actx.options(nil))
+	require.Equal(t, self.Message().ID, outgoing.Sender.ID)
+	require.Equal(t, "abc", outgoing.Headers["trace"])
+}
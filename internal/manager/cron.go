@@ -0,0 +1,194 @@
+package manager
+
+import (
+	"fmt"
+	"math/bits"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Schedule computes the occurrences of a recurring schedule.
+type Schedule interface {
+	// Next returns the first occurrence strictly after after.
+	Next(after time.Time) time.Time
+}
+
+type intervalSchedule struct {
+	interval time.Duration
+}
+
+// Every returns a schedule that occurs once per interval. Schedule rejects
+// intervals that are not positive.
+func Every(interval time.Duration) Schedule {
+	return intervalSchedule{interval: interval}
+}
+
+func (s intervalSchedule) Next(after time.Time) time.Time {
+	return after.Add(s.interval)
+}
+
+type cronField struct {
+	min  int
+	max  int
+	bits uint64
+}
+
+func (f cronField) has(value int) bool {
+	return f.bits&(1<<uint(value)) != 0
+}
+
+func (f cronField) all() bool {
+	return bits.OnesCount64(f.bits) == f.max-f.min+1
+}
+
+// CronSchedule is a standard five field cron expression: minute, hour, day of
+// month, month and day of week. If both day fields are restricted, a day
+// matches when either of them does.
+type CronSchedule struct {
+	minute cronField
+	hour   cronField
+	dom    cronField
+	month  cronField
+	dow    cronField
+}
+
+var cronDescriptors = map[string]string{
+	"@yearly":   "0 0 1 1 *",
+	"@annually": "0 0 1 1 *",
+	"@monthly":  "0 0 1 * *",
+	"@weekly":   "0 0 * * 0",
+	"@daily":    "0 0 * * *",
+	"@midnight": "0 0 * * *",
+	"@hourly":   "0 * * * *",
+}
+
+// ParseCron parses a five field cron expression or one of the @yearly,
+// @monthly, @weekly, @daily and @hourly descriptors. Fields accept *, single
+// values, ranges, lists and /step suffixes.
+func ParseCron(expr string) (*CronSchedule, error) {
+	if descriptor, ok := cronDescriptors[strings.TrimSpace(expr)]; ok {
+		expr = descriptor
+	}
+
+	fields := strings.Fields(expr)
+	if len(fields) != 5 {
+		return nil, fmt.Errorf("cron expression %q must have 5 fields", expr)
+	}
+
+	bounds := [5][2]int{{0, 59}, {0, 23}, {1, 31}, {1, 12}, {0, 7}}
+	parsed := make([]cronField, 5)
+	for i, field := range fields {
+		f, err := parseCronField(field, bounds[i][0], bounds[i][1])
+		if err != nil {
+			return nil, fmt.Errorf("cron expression %q: %w", expr, err)
+		}
+		parsed[i] = f
+	}
+
+	// Both 0 and 7 mean Sunday.
+	dow := parsed[4]
+	if dow.has(7) {
+		dow.bits |= 1
+	}
+	dow.bits &^= 1 << 7
+	dow.max = 6
+
+	return &CronSchedule{
+		minute: parsed[0],
+		hour:   parsed[1],
+		dom:    parsed[2],
+		month:  parsed[3],
+		dow:    dow,
+	}, nil
+}
+
+func parseCronField(field string, min int, max int) (cronField, error) {
+	f := cronField{min: min, max: max}
+
+	for _, part := range strings.Split(field, ",") {
+		rangePart, stepPart, hasStep := strings.Cut(part, "/")
+
+		step := 1
+		if hasStep {
+			var err error
+			if step, err = strconv.Atoi(stepPart); err != nil || step <= 0 {
+				return f, fmt.Errorf("invalid step %q", stepPart)
+			}
+		}
+
+		lo, hi := min, max
+		switch {
+		case rangePart == "*":
+		case strings.Contains(rangePart, "-"):
+			loPart, hiPart, _ := strings.Cut(rangePart, "-")
+			var err error
+			if lo, err = strconv.Atoi(loPart); err != nil {
+				return f, fmt.Errorf("invalid value %q", loPart)
+			}
+			if hi, err = strconv.Atoi(hiPart); err != nil {
+				return f, fmt.Errorf("invalid value %q", hiPart)
+			}
+		default:
+			value, err := strconv.Atoi(rangePart)
+			if err != nil {
+				return f, fmt.Errorf("invalid value %q", rangePart)
+			}
+			lo, hi = value, value
+			if hasStep {
+				hi = max
+			}
+		}
+
+		if lo < min || hi > max || lo > hi {
+			return f, fmt.Errorf("range %q outside %d-%d", rangePart, min, max)
+		}
+
+		for value := lo; value <= hi; value += step {
+			f.bits |= 1 << uint(value)
+		}
+	}
+
+	return f, nil
+}
+
+func (s *CronSchedule) matchesDay(t time.Time) bool {
+	dom := s.dom.has(t.Day())
+	dow := s.dow.has(int(t.Weekday()))
+
+	if s.dom.all() || s.dow.all() {
+		return dom && dow
+	}
+
+	return dom || dow
+}
+
+// Next returns the first matching minute after after, in after's location. It
+// returns the zero time if there is none within five years.
+func (s *CronSchedule) Next(after time.Time) time.Time {
+	t := after.Truncate(time.Minute).Add(time.Minute)
+	limit := t.AddDate(5, 0, 0)
+
+	for t.Before(limit) {
+		if !s.month.has(int(t.Month())) {
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, t.Location())
+			continue
+		}
+		if !s.matchesDay(t) {
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, t.Location())
+			continue
+		}
+		if !s.hour.has(t.Hour()) {
+			t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour()+1, 0, 0, 0, t.Location())
+			continue
+		}
+		if !s.minute.has(t.Minute()) {
+			t = t.Add(time.Minute)
+			continue
+		}
+
+		return t
+	}
+
+	return time.Time{}
+}
@@ -0,0 +1,70 @@
+package manager
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseCron(t *testing.T) {
+	tests := []struct {
+		label       string
+		expr        string
+		expectError bool
+	}{
+		{label: "every minute", expr: "* * * * *"},
+		{label: "lists ranges steps", expr: "0,30 8-18/2 1-15 */3 1-5"},
+		{label: "sunday as 7", expr: "0 0 * * 7"},
+		{label: "descriptor", expr: "@daily"},
+		{label: "too few fields", expr: "* * * *", expectError: true},
+		{label: "out of range", expr: "60 * * * *", expectError: true},
+		{label: "inverted range", expr: "* 10-5 * * *", expectError: true},
+		{label: "zero step", expr: "*/0 * * * *", expectError: true},
+		{label: "not a number", expr: "a * * * *", expectError: true},
+	}
+
+	for _, tt := range tests {
+		tf := func(t *testing.T) {
+			_, err := ParseCron(tt.expr)
+			if tt.expectError {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+		}
+
+		t.Run(tt.label, tf)
+	}
+}
+
+func TestCronNext(t *testing.T) {
+	// Saturday
+	after := time.Date(2024, 6, 1, 12, 34, 56, 0, time.UTC)
+
+	tests := []struct {
+		expr     string
+		expected time.Time
+	}{
+		{expr: "* * * * *", expected: time.Date(2024, 6, 1, 12, 35, 0, 0, time.UTC)},
+		{expr: "@hourly", expected: time.Date(2024, 6, 1, 13, 0, 0, 0, time.UTC)},
+		{expr: "@daily", expected: time.Date(2024, 6, 2, 0, 0, 0, 0, time.UTC)},
+		{expr: "@weekly", expected: time.Date(2024, 6, 2, 0, 0, 0, 0, time.UTC)},
+		{expr: "0 0 * * 7", expected: time.Date(2024, 6, 2, 0, 0, 0, 0, time.UTC)},
+		{expr: "*/15 9-17 * * 1-5", expected: time.Date(2024, 6, 3, 9, 0, 0, 0, time.UTC)},
+		{expr: "0 0 29 2 *", expected: time.Date(2028, 2, 29, 0, 0, 0, 0, time.UTC)},
+		{expr: "0 12 13 * 5", expected: time.Date(2024, 6, 7, 12, 0, 0, 0, time.UTC)},
+		{expr: "0 0 31 4 *", expected: time.Time{}},
+	}
+
+	for _, tt := range tests {
+		tf := func(t *testing.T) {
+			schedule, err := ParseCron(tt.expr)
+			require.NoError(t, err)
+
+			require.Equal(t, tt.expected, schedule.Next(after))
+		}
+
+		t.Run(tt.expr, tf)
+	}
+}
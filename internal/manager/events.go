@@ -0,0 +1,156 @@
+package manager
+
+import (
+	"context"
+	"log/slog"
+	"slices"
+	"time"
+
+	"github.com/gnarloqgames/ga-actor-poc/internal/model"
+	"github.com/google/uuid"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+const (
+	AttributeTopic        string = "topic"
+	AttributeEvent        string = "event"
+	AttributeSubscription string = "subscription_id"
+
+	// HeaderTopic is the envelope header telling an actor subscriber the
+	// topic an event was published on.
+	HeaderTopic string = "topic"
+
+	EventDeliveryTimeout = 10 * time.Second
+)
+
+// Event is an event received by a channel subscriber.
+type Event struct {
+	Topic   string
+	Message proto.Message
+	// Publisher is unset when the event does not come from an actor.
+	Publisher model.Address
+}
+
+// Filter selects the events a subscription receives. An empty field matches
+// every event.
+type Filter struct {
+	Topic string
+	Type  protoreflect.FullName
+}
+
+// TypeOf returns the type of msg to filter events on.
+func TypeOf(msg proto.Message) protoreflect.FullName {
+	return msg.ProtoReflect().Descriptor().FullName()
+}
+
+func (f Filter) matches(topic string, event proto.Message) bool {
+	return (f.Topic == "" || f.Topic == topic) && (f.Type == "" || f.Type == TypeOf(event))
+}
+
+type subscription struct {
+	id      uuid.UUID
+	filter  Filter
+	address model.Address
+	channel chan<- Event
+}
+
+// Subscribe makes the manager send every event matching filter to the actor
+// at address. The topic of the event is in the HeaderTopic header of its
+// envelope. The subscription ends with Unsubscribe or when the actor stops.
+func (m *Manager) Subscribe(filter Filter, address model.Address) uuid.UUID {
+	return m.subscribe(&subscription{filter: filter, address: address})
+}
+
+// SubscribeChan sends every event matching filter on ch. Events that do not
+// fit in ch are dropped, so ch should be buffered and drained promptly.
+func (m *Manager) SubscribeChan(filter Filter, ch chan<- Event) uuid.UUID {
+	return m.subscribe(&subscription{filter: filter, channel: ch})
+}
+
+func (m *Manager) subscribe(s *subscription) uuid.UUID {
+	s.id = m.newID()
+
+	m.eventMx.Lock()
+	defer m.eventMx.Unlock()
+
+	m.subscriptions = append(m.subscriptions, s)
+
+	return s.id
+}
+
+// Unsubscribe ends a subscription. It reports false if there was none with id.
+func (m *Manager) Unsubscribe(id uuid.UUID) bool {
+	m.eventMx.Lock()
+	defer m.eventMx.Unlock()
+
+	n := len(m.subscriptions)
+	m.subscriptions = slices.DeleteFunc(m.subscriptions, func(s *subscription) bool { return s.id == id })
+
+	return len(m.subscriptions) < n
+}
+
+// unsubscribeActor ends the subscriptions of the actor at address.
+func (m *Manager) unsubscribeActor(address model.Address) {
+	m.eventMx.Lock()
+	defer m.eventMx.Unlock()
+
+	m.subscriptions = slices.DeleteFunc(m.subscriptions, func(s *subscription) bool {
+		return s.channel == nil && s.address == address
+	})
+}
+
+// Publish hands event to every subscriber of topic and of its type, in the
+// order they subscribed, without waiting for them. Actor subscribers each
+// receive a copy of event. When ctx is the context of
+// a Receive call, the receiving actor is the publisher.
+func (m *Manager) Publish(ctx context.Context, topic string, event proto.Message) {
+	var publisher model.Address
+	if actx, ok := model.ActorContextFromContext(ctx); ok {
+		publisher = actx.Self()
+	}
+
+	m.eventMx.Lock()
+	subscriptions := slices.Clone(m.subscriptions)
+	m.eventMx.Unlock()
+
+	for _, s := range subscriptions {
+		if !s.filter.matches(topic, event) {
+			continue
+		}
+
+		if s.channel != nil {
+			select {
+			case s.channel <- Event{Topic: topic, Message: event, Publisher: publisher}:
+			default:
+				slog.Warn("event dropped",
+					AttributeSubscription, s.id.String(),
+					AttributeTopic, topic,
+					AttributeEvent, string(TypeOf(event)),
+				)
+			}
+
+			continue
+		}
+
+		opts := []model.SendOption{model.WithHeader(HeaderTopic, topic)}
+		if publisher != (model.Address{}) {
+			opts = append(opts, model.WithSender(publisher))
+		}
+
+		// Every actor gets its own copy, so that none of them can change
+		// the event under the others or the publisher.
+		msg := proto.Clone(event)
+		m.executor.Go(func() {
+			err := m.Send(context.Background(), s.address, msg, EventDeliveryTimeout, opts...)
+			if err != nil {
+				slog.Error("failed to deliver event",
+					AttributeSubscription, s.id.String(),
+					AttributeTopic, topic,
+					AttributeEvent, string(TypeOf(event)),
+					"error", err,
+				)
+			}
+		})
+	}
+}
@@ -0,0 +1,139 @@
+package manager
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/gnarloqgames/ga-actor-poc/internal/clock"
+	"github.com/gnarloqgames/ga-actor-poc/internal/model"
+	"github.com/gnarloqgames/ga-actor-poc/message"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+func TestPublish(t *testing.T) {
+	text := wrapperspb.String("text")
+	build := &message.BuildingCompleted{Name: "farm"}
+
+	tests := []struct {
+		label    string
+		filter   Filter
+		expected []proto.Message
+	}{
+		{
+			label:    "everything",
+			filter:   Filter{},
+			expected: []proto.Message{text, build, text},
+		},
+		{
+			label:    "topic",
+			filter:   Filter{Topic: "inventory"},
+			expected: []proto.Message{text, build},
+		},
+		{
+			label:    "type",
+			filter:   Filter{Type: TypeOf(text)},
+			expected: []proto.Message{text, text},
+		},
+		{
+			label:    "topic and type",
+			filter:   Filter{Topic: "inventory", Type: TypeOf(build)},
+			expected: []proto.Message{build},
+		},
+	}
+
+	for _, tt := range tests {
+		tf := func(t *testing.T) {
+			manager := NewManager()
+			ch := make(chan Event, 10)
+			manager.SubscribeChan(tt.filter, ch)
+
+			manager.Publish(context.Background(), "inventory", text)
+			manager.Publish(context.Background(), "inventory", build)
+			manager.Publish(context.Background(), "market", text)
+
+			require.Len(t, ch, len(tt.expected))
+			for _, expected := range tt.expected {
+				event := <-ch
+				require.True(t, proto.Equal(expected, event.Message))
+				require.Equal(t, model.Address{}, event.Publisher)
+			}
+		}
+
+		t.Run(tt.label, tf)
+	}
+}
+
+func TestUnsubscribe(t *testing.T) {
+	manager := NewManager()
+	ch := make(chan Event, 1)
+	id := manager.SubscribeChan(Filter{}, ch)
+
+	require.True(t, manager.Unsubscribe(id))
+	require.False(t, manager.Unsubscribe(id))
+
+	manager.Publish(context.Background(), "inventory", wrapperspb.String("text"))
+	require.Empty(t, ch)
+}
+
+func TestPublishFullChannel(t *testing.T) {
+	manager := NewManager()
+	ch := make(chan Event, 1)
+	manager.SubscribeChan(Filter{}, ch)
+
+	manager.Publish(context.Background(), "inventory", wrapperspb.String("first"))
+	manager.Publish(context.Background(), "inventory", wrapperspb.String("second"))
+
+	require.Equal(t, "first", (<-ch).Message.(*wrapperspb.StringValue).Value)
+	require.Empty(t, ch)
+}
+
+func TestSubscribeActor(t *testing.T) {
+	manager := NewManager()
+	envelopes := make(chan *message.Envelope, 10)
+	ids := make(chan uuid.UUID, 10)
+	newTestKind(t, manager, "relay", testActor{receive: relay(manager, nil, envelopes, ids)})
+
+	publisher := model.Address{Kind: "relay", ID: uuid.New()}
+	subscriber := model.Address{Kind: "relay", ID: uuid.New()}
+	manager.Context(subscriber).Subscribe("inventory", "")
+
+	manager.Context(publisher).Publish(context.Background(), "inventory", wrapperspb.String("text"))
+
+	select {
+	case envelope := <-envelopes:
+		require.Equal(t, subscriber.ID, <-ids)
+		require.Equal(t, "inventory", envelope.Headers[HeaderTopic])
+		require.Equal(t, publisher.ID.String(), envelope.Sender.GetID())
+	case <-time.After(time.Second):
+		t.Fatal("event was not delivered")
+	}
+
+	require.NoError(t, manager.Stop(context.Background(), subscriber))
+	manager.eventMx.Lock()
+	defer manager.eventMx.Unlock()
+	require.Empty(t, manager.subscriptions)
+}
+
+func TestPublishCopiesEvent(t *testing.T) {
+	manager, received := newRecordingManager(t, clock.Real)
+	for range 2 {
+		manager.Context(model.Address{Kind: "recorder", ID: uuid.New()}).Subscribe("inventory", "")
+	}
+
+	event := wrapperspb.String("text")
+	manager.Publish(context.Background(), "inventory", event)
+
+	first := (<-received).(*wrapperspb.StringValue)
+	second := (<-received).(*wrapperspb.StringValue)
+	require.NotSame(t, event, first)
+	require.NotSame(t, event, second)
+	require.NotSame(t, first, second)
+
+	first.Value = "changed"
+	require.Equal(t, "text", second.Value)
+	require.Equal(t, "text", event.Value)
+}
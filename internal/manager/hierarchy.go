@@ -0,0 +1,305 @@
+package manager
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"slices"
+
+	"github.com/gnarloqgames/ga-actor-poc/internal/model"
+	"github.com/gnarloqgames/ga-actor-poc/message"
+)
+
+const (
+	AttributeParentKind string = "parent_kind"
+	AttributeParentID   string = "parent_id"
+	AttributeChildKind  string = "child_kind"
+	AttributeChildID    string = "child_id"
+)
+
+// family links an actor to its parent and children. Actors get one when they
+// spawn a child, are spawned or are being stopped.
+type family struct {
+	parent    model.Address
+	hasParent bool
+	children  []model.Address
+	stopping  bool
+}
+
+func familyAttributes(parent model.Address, child model.Address) []any {
+	return []any{
+		AttributeParentKind, parent.Kind,
+		AttributeParentID, parent.ID.String(),
+		AttributeChildKind, child.Kind,
+		AttributeChildID, child.ID.String(),
+	}
+}
+
+// family returns the family of address, creating it if needed. The caller must
+// hold m.familyMx.
+func (m *Manager) family(address model.Address) *family {
+	f, ok := m.families[address]
+	if !ok {
+		f = &family{children: make([]model.Address, 0)}
+		m.families[address] = f
+	}
+
+	return f
+}
+
+// Parent returns the address of the actor that spawned the actor at address.
+func (m *Manager) Parent(address model.Address) (model.Address, bool) {
+	m.familyMx.Lock()
+	defer m.familyMx.Unlock()
+
+	f, ok := m.families[address]
+	if !ok || !f.hasParent {
+		return model.Address{}, false
+	}
+
+	return f.parent, true
+}
+
+// Children returns the running children of the actor at address in the order
+// they were spawned.
+func (m *Manager) Children(address model.Address) []model.Address {
+	m.familyMx.Lock()
+	defer m.familyMx.Unlock()
+
+	f, ok := m.families[address]
+	if !ok {
+		return []model.Address{}
+	}
+
+	return slices.Clone(f.children)
+}
+
+// Spawn activates a new actor of kind as a child of parent. The factory of the
+// kind already sees parent through its actor context.
+func (m *Manager) Spawn(parent model.Address, kind string) (model.Address, error) {
+	collection, err := m.collection(kind)
+	if err != nil {
+		return model.Address{}, err
+	}
+
+	child := model.Address{Kind: kind, ID: m.newID()}
+	if err := m.adopt(parent, child); err != nil {
+		return model.Address{}, err
+	}
+
+	if collection.Get(child) == nil {
+		m.disown(child)
+
+		return model.Address{}, fmt.Errorf("kind %s can only be spawned with SpawnActor", kind)
+	}
+
+	slog.Info("spawned child actor", familyAttributes(parent, child)...)
+
+	return child, nil
+}
+
+// SpawnActor makes child, created by the caller, a child of parent. It is
+// addressable by its kind and ID until it is stopped, even if its kind has no
+// factory.
+func (m *Manager) SpawnActor(parent model.Address, child model.Actor) (model.Address, error) {
+	address := model.Address{Kind: child.GetKind(), ID: child.GetID()}
+
+	m.kindMx.Lock()
+	collection, ok := m.actors[address.Kind]
+	if !ok {
+		collection = m.newCollection(nil)
+		m.actors[address.Kind] = collection
+	}
+	m.kindMx.Unlock()
+
+	if err := m.adopt(parent, address); err != nil {
+		return model.Address{}, err
+	}
+
+	if !collection.Put(child) {
+		m.disown(address)
+
+		return model.Address{}, fmt.Errorf("actor %s/%s is already running", address.Kind, address.ID)
+	}
+
+	slog.Info("spawned child actor", familyAttributes(parent, address)...)
+
+	return address, nil
+}
+
+func (m *Manager) adopt(parent model.Address, child model.Address) error {
+	m.familyMx.Lock()
+	defer m.familyMx.Unlock()
+
+	if f, ok := m.families[parent]; ok && f.stopping {
+		return fmt.Errorf("%s/%s is stopping", parent.Kind, parent.ID)
+	}
+	if _, ok := m.families[child]; ok {
+		return fmt.Errorf("actor %s/%s is already running", child.Kind, child.ID)
+	}
+
+	m.family(parent).children = append(m.family(parent).children, child)
+	m.family(child).parent = parent
+	m.family(child).hasParent = true
+
+	return nil
+}
+
+// disown removes child from the family of its parent and forgets its own.
+func (m *Manager) disown(child model.Address) {
+	m.familyMx.Lock()
+	defer m.familyMx.Unlock()
+
+	f, ok := m.families[child]
+	if !ok {
+		return
+	}
+	delete(m.families, child)
+
+	if !f.hasParent {
+		return
+	}
+
+	if parent, ok := m.families[f.parent]; ok {
+		parent.children = slices.DeleteFunc(parent.children, func(a model.Address) bool {
+			return a == child
+		})
+	}
+}
+
+// Stop destroys the running actor at address after stopping its children in
+// the order they were spawned. Its parent, if it has one and is not stopping too, receives a
+// message.Terminated. A stopped actor is activated again by the next message
+// sent to it.
+func (m *Manager) Stop(ctx context.Context, address model.Address) error {
+	return m.stop(ctx, address, ReasonStopped, true)
+}
+
+// stop destroys the actor at address and its children. Only the parent of the
+// actor at address is notified, and only if notify is set, as the parents of
+// its children are stopping.
+func (m *Manager) stop(ctx context.Context, address model.Address, reason string, notify bool) error {
+	collection, err := m.collection(address.Kind)
+	if err != nil {
+		return err
+	}
+	if _, ok := collection.Lookup(address); !ok {
+		return fmt.Errorf("%w: %s/%s", model.ErrNotActive, address.Kind, address.ID)
+	}
+
+	m.familyMx.Lock()
+	f := m.family(address)
+	if f.stopping {
+		m.familyMx.Unlock()
+
+		return fmt.Errorf("%w: %s/%s is already stopping", model.ErrNotActive, address.Kind, address.ID)
+	}
+	f.stopping = true
+	children := slices.Clone(f.children)
+	parent, hasParent := f.parent, f.hasParent
+	m.familyMx.Unlock()
+
+	for _, child := range children {
+		if err := m.stop(ctx, child, reason, false); err != nil {
+			slog.Error("failed to stop child actor", append(familyAttributes(address, child), "error", err)...)
+		}
+	}
+
+	if actor, ok := collection.terminate(address, reason); ok {
+		actor.Destroy(model.WithActorContext(ctx, m.Context(address)))
+	}
+	m.disown(address)
+
+	slog.Info("actor stopped",
+		"actor_kind", address.Kind,
+		"actor_id", address.ID,
+		AttributeReason, reason,
+	)
+
+	watchers := m.terminated(address, reason)
+	m.unsubscribeActor(address)
+
+	if notify && hasParent && !slices.Contains(watchers, parent) {
+		m.executor.Go(func() { m.notifyParent(parent, address, reason) })
+	}
+
+	return nil
+}
+
+// Passivate destroys the running actor at address to free its memory. Its
+// watchers and children are terminated with ReasonPassivated, the children as
+// they do not outlive it in memory. Unlike a stopped actor, its parent and
+// subscriptions keep it, as do the watches it holds, and the next message sent
+// to it activates it again. Actors of kinds without a factory cannot be
+// passivated, since nothing could activate them again.
+func (m *Manager) Passivate(ctx context.Context, address model.Address) error {
+	collection, err := m.collection(address.Kind)
+	if err != nil {
+		return err
+	}
+	if !collection.hasFactory() {
+		return fmt.Errorf("kind %s has no factory to activate %s again", address.Kind, address.ID)
+	}
+	if _, ok := collection.Lookup(address); !ok {
+		return fmt.Errorf("%w: %s/%s", model.ErrNotActive, address.Kind, address.ID)
+	}
+
+	m.familyMx.Lock()
+	f, hasFamily := m.families[address]
+	if hasFamily && f.stopping {
+		m.familyMx.Unlock()
+
+		return fmt.Errorf("%w: %s/%s is already stopping", model.ErrNotActive, address.Kind, address.ID)
+	}
+	children := make([]model.Address, 0)
+	if hasFamily {
+		f.stopping = true
+		children = slices.Clone(f.children)
+	}
+	m.familyMx.Unlock()
+
+	for _, child := range children {
+		if err := m.stop(ctx, child, ReasonPassivated, false); err != nil {
+			slog.Error("failed to stop child actor", append(familyAttributes(address, child), "error", err)...)
+		}
+	}
+
+	if actor, ok := collection.terminate(address, ReasonPassivated); ok {
+		actor.Destroy(model.WithActorContext(ctx, m.Context(address)))
+	}
+	m.notifyWatchers(address, ReasonPassivated)
+
+	if hasFamily {
+		m.familyMx.Lock()
+		f.stopping = false
+		m.familyMx.Unlock()
+	}
+
+	slog.Info("actor passivated",
+		"actor_kind", address.Kind,
+		"actor_id", address.ID,
+	)
+
+	return nil
+}
+
+// notifyParent tells a running parent that child has stopped.
+func (m *Manager) notifyParent(parent model.Address, child model.Address, reason string) {
+	collection, err := m.collection(parent.Kind)
+	if err != nil {
+		return
+	}
+	if _, ok := collection.Lookup(parent); !ok {
+		return
+	}
+
+	terminated := &message.Terminated{
+		Actor:  child.Message(),
+		Reason: reason,
+	}
+
+	err = m.Send(context.Background(), parent, terminated, TerminatedDeliveryTimeout, model.WithSender(child))
+	if err != nil {
+		slog.Error("failed to notify parent of terminated child", append(familyAttributes(parent, child), "error", err)...)
+	}
+}
@@ -0,0 +1,180 @@
+package manager
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/gnarloqgames/ga-actor-poc/internal/model"
+	"github.com/gnarloqgames/ga-actor-poc/message"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+// watchFamily records the actors it is told have terminated and panics with the
+// value of any string it receives.
+func watchFamily(terminated chan *message.Terminated) func(ctx context.Context, msg proto.Message, res proto.Message) error {
+	return func(ctx context.Context, msg proto.Message, res proto.Message) error {
+		switch msg := msg.(type) {
+		case *message.Terminated:
+			terminated <- msg
+		case *wrapperspb.StringValue:
+			panic(msg.Value)
+		}
+
+		return nil
+	}
+}
+
+func newFamilyManager(t *testing.T) (*Manager, chan model.Address, chan *message.Terminated) {
+	t.Helper()
+
+	manager := NewManager()
+	destroyed := make(chan model.Address, 10)
+	terminated := make(chan *message.Terminated, 10)
+	newTestKind(t, manager, "family", testActor{
+		receive: watchFamily(terminated),
+		destroy: func(address model.Address) { destroyed <- address },
+	})
+
+	return manager, destroyed, terminated
+}
+
+func TestSpawn(t *testing.T) {
+	manager, _, _ := newFamilyManager(t)
+	parent := model.Address{Kind: "family", ID: uuid.New()}
+	_, err := manager.Actor(parent)
+	require.NoError(t, err)
+
+	first, err := manager.Context(parent).Spawn("family")
+	require.NoError(t, err)
+	second, err := manager.Context(parent).SpawnActor(&testActor{id: uuid.New(), kind: "helper"})
+	require.NoError(t, err)
+	require.Equal(t, "helper", second.Kind)
+
+	require.Equal(t, []model.Address{first, second}, manager.Context(parent).Children())
+
+	got, ok := manager.Context(first).Parent()
+	require.True(t, ok)
+	require.Equal(t, parent, got)
+
+	_, ok = manager.Context(parent).Parent()
+	require.False(t, ok)
+
+	actor, err := manager.Actor(second)
+	require.NoError(t, err)
+	require.Equal(t, second.ID, actor.GetID())
+
+	_, err = manager.Context(parent).Spawn("missing")
+	require.Error(t, err)
+}
+
+func TestStopCascades(t *testing.T) {
+	manager, destroyed, terminated := newFamilyManager(t)
+	root := model.Address{Kind: "family", ID: uuid.New()}
+	_, err := manager.Actor(root)
+	require.NoError(t, err)
+
+	child, err := manager.Spawn(root, "family")
+	require.NoError(t, err)
+	grandchild, err := manager.Spawn(child, "family")
+	require.NoError(t, err)
+	sibling, err := manager.Spawn(root, "family")
+	require.NoError(t, err)
+
+	err = manager.Stop(context.Background(), child)
+	require.NoError(t, err)
+	require.Equal(t, grandchild, <-destroyed)
+	require.Equal(t, child, <-destroyed)
+	require.Equal(t, []model.Address{sibling}, manager.Children(root))
+
+	select {
+	case msg := <-terminated:
+		require.Equal(t, child.Message().ID, msg.Actor.ID)
+		require.Equal(t, ReasonStopped, msg.Reason)
+	case <-time.After(time.Second):
+		t.Fatal("parent was not notified")
+	}
+
+	err = manager.Stop(context.Background(), root)
+	require.NoError(t, err)
+	require.Equal(t, sibling, <-destroyed)
+	require.Equal(t, root, <-destroyed)
+	require.Empty(t, manager.Children(root))
+
+	err = manager.Stop(context.Background(), root)
+	require.ErrorIs(t, err, model.ErrNotActive)
+
+	_, err = manager.Actor(grandchild)
+	require.NoError(t, err)
+	_, ok := manager.Parent(grandchild)
+	require.False(t, ok, "a stopped child is activated again without a parent")
+}
+
+func TestActorContextStop(t *testing.T) {
+	manager, destroyed, _ := newFamilyManager(t)
+	parent := model.Address{Kind: "family", ID: uuid.New()}
+	stranger := model.Address{Kind: "family", ID: uuid.New()}
+	_, err := manager.Actor(stranger)
+	require.NoError(t, err)
+
+	child, err := manager.Spawn(parent, "family")
+	require.NoError(t, err)
+
+	err = manager.Context(parent).Stop(context.Background(), stranger)
+	require.ErrorIs(t, err, model.ErrNotActive)
+
+	err = manager.Context(parent).Stop(context.Background(), child)
+	require.NoError(t, err)
+	require.Equal(t, child, <-destroyed)
+}
+
+func TestPassivate(t *testing.T) {
+	manager, destroyed, terminated := newFamilyManager(t)
+	root := model.Address{Kind: "family", ID: uuid.New()}
+	watcher := model.Address{Kind: "family", ID: uuid.New()}
+	for _, address := range []model.Address{root, watcher} {
+		_, err := manager.Actor(address)
+		require.NoError(t, err)
+	}
+
+	child, err := manager.Spawn(root, "family")
+	require.NoError(t, err)
+	grandchild, err := manager.Spawn(child, "family")
+	require.NoError(t, err)
+	require.NoError(t, manager.Watch(watcher, child))
+
+	err = manager.Passivate(context.Background(), child)
+	require.NoError(t, err)
+	require.Equal(t, grandchild, <-destroyed)
+	require.Equal(t, child, <-destroyed)
+
+	_, _, err = manager.Lookup(child)
+	require.ErrorIs(t, err, model.ErrNotActive)
+	require.Empty(t, manager.Children(child))
+	require.Equal(t, []model.Address{child}, manager.Children(root))
+	parent, ok := manager.Parent(child)
+	require.True(t, ok)
+	require.Equal(t, root, parent)
+
+	// Only the watcher is told, the parent keeps its child.
+	expectTerminated(t, terminated, child, ReasonPassivated)
+
+	err = manager.Passivate(context.Background(), child)
+	require.ErrorIs(t, err, model.ErrNotActive)
+	require.NoError(t, manager.Watch(watcher, child))
+	expectTerminated(t, terminated, child, ReasonPassivated)
+
+	_, err = manager.Actor(child)
+	require.NoError(t, err)
+	require.NoError(t, manager.Watch(watcher, child))
+	require.NoError(t, manager.Stop(context.Background(), child))
+	<-destroyed
+	for range 2 {
+		msg := <-terminated
+		require.Equal(t, child.Message().ID, msg.Actor.ID)
+		require.Equal(t, ReasonStopped, msg.Reason)
+	}
+}
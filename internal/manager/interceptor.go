@@ -0,0 +1,139 @@
+package manager
+
+import (
+	"context"
+	"log/slog"
+	"slices"
+
+	"github.com/gnarloqgames/ga-actor-poc/internal/model"
+	"github.com/gnarloqgames/ga-actor-poc/message"
+	"google.golang.org/protobuf/proto"
+)
+
+// Delivery is a message on its way to an actor. Interceptors may change any
+// of its fields before passing it on.
+type Delivery struct {
+	Address  model.Address
+	Envelope *message.Envelope
+	Message  proto.Message
+	// Response is nil when the sender does not expect one.
+	Response proto.Message
+}
+
+// SendHandler hands a delivery over to the actor it is addressed to.
+type SendHandler func(ctx context.Context, d *Delivery) error
+
+// ReceiveHandler has actor receive a delivery.
+type ReceiveHandler func(ctx context.Context, actor model.Actor, d *Delivery) error
+
+// Interceptor wraps the send side of a delivery, before the recipient is
+// activated, and its receive side, around Receive with the context of the
+// recipient. Either side can be nil. An interceptor rejects a delivery by
+// returning an error and short-circuits it by returning without calling next.
+type Interceptor struct {
+	Send    func(next SendHandler) SendHandler
+	Receive func(next ReceiveHandler) ReceiveHandler
+}
+
+// Use adds interceptors for every kind, after those already added. Global
+// interceptors run before the interceptors of a kind. Logging is added by
+// NewManager.
+func (m *Manager) Use(interceptors ...Interceptor) {
+	m.kindMx.Lock()
+	defer m.kindMx.Unlock()
+
+	m.interceptors = append(m.interceptors, interceptors...)
+}
+
+// UseKind adds interceptors for the messages delivered to actors of kind.
+func (m *Manager) UseKind(kind string, interceptors ...Interceptor) {
+	m.kindMx.Lock()
+	defer m.kindMx.Unlock()
+
+	m.kindInterceptors[kind] = append(m.kindInterceptors[kind], interceptors...)
+}
+
+// chain returns the interceptors of kind, outermost first.
+func (m *Manager) chain(kind string) []Interceptor {
+	m.kindMx.RLock()
+	defer m.kindMx.RUnlock()
+
+	return slices.Concat(m.interceptors, m.kindInterceptors[kind])
+}
+
+func (m *Manager) send(ctx context.Context, d *Delivery) error {
+	handler := SendHandler(m.deliver)
+
+	chain := m.chain(d.Address.Kind)
+	for i := len(chain) - 1; i >= 0; i-- {
+		if chain[i].Send != nil {
+			handler = chain[i].Send(handler)
+		}
+	}
+
+	return handler(ctx, d)
+}
+
+func (m *Manager) receiveChain(kind string) ReceiveHandler {
+	handler := ReceiveHandler(func(ctx context.Context, actor model.Actor, d *Delivery) error {
+		return actor.Receive(ctx, d.Message, d.Response)
+	})
+
+	chain := m.chain(kind)
+	for i := len(chain) - 1; i >= 0; i-- {
+		if chain[i].Receive != nil {
+			handler = chain[i].Receive(handler)
+		}
+	}
+
+	return handler
+}
+
+func messageName(msg proto.Message) string {
+	if msg == nil {
+		return ""
+	}
+
+	return string(msg.ProtoReflect().Descriptor().FullName())
+}
+
+// Logging logs every message sent and the errors actors return.
+func Logging() Interceptor {
+	return Interceptor{
+		Send: func(next SendHandler) SendHandler {
+			return func(ctx context.Context, d *Delivery) error {
+				slog.Info("sending message to actor",
+					"recipient_kind", d.Address.Kind,
+					"recipient_id", d.Address.ID,
+					"correlation_id", d.Envelope.GetCorrelationID(),
+					"message", messageName(d.Message),
+				)
+
+				return next(ctx, d)
+			}
+		},
+		Receive: func(next ReceiveHandler) ReceiveHandler {
+			return func(ctx context.Context, actor model.Actor, d *Delivery) error {
+				slog.Info("actor received message",
+					"actor_kind", actor.GetKind(),
+					"actor_id", actor.GetID(),
+					"correlation_id", d.Envelope.GetCorrelationID(),
+					"message", messageName(d.Message),
+				)
+
+				err := next(ctx, actor, d)
+				if err != nil {
+					slog.Warn("actor failed to handle message",
+						"actor_kind", actor.GetKind(),
+						"actor_id", actor.GetID(),
+						"correlation_id", d.Envelope.GetCorrelationID(),
+						"message", messageName(d.Message),
+						"error", err,
+					)
+				}
+
+				return err
+			}
+		},
+	}
+}
@@ -0,0 +1,124 @@
+package manager
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/gnarloqgames/ga-actor-poc/internal/clock"
+	"github.com/gnarloqgames/ga-actor-poc/internal/model"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+// tracing records the side and name of every interceptor call in calls.
+func tracing(name string, calls *[]string) Interceptor {
+	return Interceptor{
+		Send: func(next SendHandler) SendHandler {
+			return func(ctx context.Context, d *Delivery) error {
+				*calls = append(*calls, "send "+name)
+				return next(ctx, d)
+			}
+		},
+		Receive: func(next ReceiveHandler) ReceiveHandler {
+			return func(ctx context.Context, actor model.Actor, d *Delivery) error {
+				*calls = append(*calls, "receive "+name)
+				return next(ctx, actor, d)
+			}
+		},
+	}
+}
+
+func TestInterceptorOrder(t *testing.T) {
+	manager, received := newRecordingManager(t, clock.Real)
+	calls := make([]string, 0)
+
+	manager.UseKind("recorder", tracing("kind", &calls))
+	manager.Use(tracing("first", &calls), tracing("second", &calls))
+	manager.UseKind("other", tracing("other", &calls))
+
+	err := manager.Send(context.Background(), model.Address{Kind: "recorder", ID: uuid.New()}, wrapperspb.String("hello"), time.Second)
+	require.NoError(t, err)
+	expectMessage(t, received, "hello", time.Second)
+
+	require.Equal(t, []string{
+		"send first", "send second", "send kind",
+		"receive first", "receive second", "receive kind",
+	}, calls)
+}
+
+func TestInterceptor(t *testing.T) {
+	errRejected := errors.New("rejected")
+
+	tests := []struct {
+		label         string
+		interceptor   Interceptor
+		expectedError error
+		expected      string
+		expectedRes   string
+		activated     bool
+	}{
+		{
+			label: "modify",
+			interceptor: Interceptor{
+				Receive: func(next ReceiveHandler) ReceiveHandler {
+					return func(ctx context.Context, actor model.Actor, d *Delivery) error {
+						d.Message = wrapperspb.String("modified")
+						return next(ctx, actor, d)
+					}
+				},
+			},
+			expected:  "modified",
+			activated: true,
+		},
+		{
+			label: "reject on send",
+			interceptor: Interceptor{
+				Send: func(next SendHandler) SendHandler {
+					return func(ctx context.Context, d *Delivery) error {
+						return errRejected
+					}
+				},
+			},
+			expectedError: errRejected,
+		},
+		{
+			label: "short-circuit on receive",
+			interceptor: Interceptor{
+				Receive: func(next ReceiveHandler) ReceiveHandler {
+					return func(ctx context.Context, actor model.Actor, d *Delivery) error {
+						d.Response.(*wrapperspb.StringValue).Value = "cached"
+						return nil
+					}
+				},
+			},
+			expectedRes: "cached",
+			activated:   true,
+		},
+	}
+
+	for _, tt := range tests {
+		tf := func(t *testing.T) {
+			manager, received := newRecordingManager(t, clock.Real)
+			manager.UseKind("recorder", tt.interceptor)
+			address := model.Address{Kind: "recorder", ID: uuid.New()}
+
+			res := &wrapperspb.StringValue{}
+			err := manager.Ask(context.Background(), address, wrapperspb.String("hello"), res, time.Second)
+			require.ErrorIs(t, err, tt.expectedError)
+			require.Equal(t, tt.expectedRes, res.Value)
+
+			if tt.expected != "" {
+				expectMessage(t, received, tt.expected, time.Second)
+			}
+			expectNoMessage(t, received, 10*time.Millisecond)
+
+			_, ok := manager.actors["recorder"].Lookup(address)
+			require.Equal(t, tt.activated, ok)
+		}
+
+		t.Run(tt.label, tf)
+	}
+}
@@ -4,47 +4,300 @@ import (
 	"context"
 	"fmt"
 	"log/slog"
+	"maps"
+	"math/rand/v2"
+	"sync"
 	"time"
 
+	"github.com/gnarloqgames/ga-actor-poc/internal/clock"
+	"github.com/gnarloqgames/ga-actor-poc/internal/executor"
 	"github.com/gnarloqgames/ga-actor-poc/internal/model"
+	"github.com/gnarloqgames/ga-actor-poc/message"
+	"github.com/google/uuid"
 	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
-type actorFactory func(ctx context.Context) model.Actor
+type actorFactory = func(ctx context.Context) model.Actor
+
+var (
+	_ model.Asker        = (*Manager)(nil)
+	_ model.KindRegistry = (*Manager)(nil)
+)
 
 type Manager struct {
-	actors map[string]*ActorCollection
+	kindMx   *sync.RWMutex
+	actors   map[string]*ActorCollection
+	clock    clock.Clock
+	executor executor.Executor
+
+	timerMx    *sync.Mutex
+	timerStore TimerStore
+	timers     map[uuid.UUID]*pendingTimer
+	schedules  map[uuid.UUID]*recurring
+	random     *rand.Rand
+
+	stateMx    *sync.Mutex
+	stateStore StateStore
+
+	familyMx *sync.Mutex
+	families map[model.Address]*family
+
+	watchMx  *sync.Mutex
+	watchers map[model.Address][]model.Address
+	watching map[model.Address][]model.Address
+
+	eventMx       *sync.Mutex
+	subscriptions []*subscription
+
+	idMx *sync.Mutex
+	ids  *rand.Rand
+
+	interceptors     []Interceptor
+	kindInterceptors map[string][]Interceptor
 }
 
 func NewManager() *Manager {
+	return NewManagerWithClock(clock.Real)
+}
+
+// NewManagerWithClock creates a manager whose timers, schedules and actors
+// run on the given clock.
+func NewManagerWithClock(c clock.Clock) *Manager {
 	return &Manager{
-		actors: make(map[string]*ActorCollection),
+		kindMx:   &sync.RWMutex{},
+		actors:   make(map[string]*ActorCollection),
+		clock:    c,
+		executor: executor.Default,
+
+		timerMx:    &sync.Mutex{},
+		timerStore: NewMemoryTimerStore(),
+		timers:     make(map[uuid.UUID]*pendingTimer),
+		schedules:  make(map[uuid.UUID]*recurring),
+		random:     rand.New(rand.NewPCG(rand.Uint64(), rand.Uint64())),
+
+		stateMx:    &sync.Mutex{},
+		stateStore: NewMemoryStateStore(),
+
+		familyMx: &sync.Mutex{},
+		families: make(map[model.Address]*family),
+
+		watchMx:  &sync.Mutex{},
+		watchers: make(map[model.Address][]model.Address),
+		watching: make(map[model.Address][]model.Address),
+
+		eventMx:       &sync.Mutex{},
+		subscriptions: make([]*subscription, 0),
+
+		idMx: &sync.Mutex{},
+
+		interceptors:     []Interceptor{Logging()},
+		kindInterceptors: make(map[string][]Interceptor),
 	}
 }
 
+// UseExecutor makes every actor of every kind, including kinds already
+// registered, run its background work on e. Actors that are already active keep
+// the executor they were created with.
+func (m *Manager) UseExecutor(e executor.Executor) {
+	m.kindMx.Lock()
+	defer m.kindMx.Unlock()
+
+	m.executor = e
+	for _, collection := range m.actors {
+		collection.executor = e
+	}
+}
+
+// UseRandom replaces the source of the random jitter added to schedules.
+func (m *Manager) UseRandom(r *rand.Rand) {
+	m.timerMx.Lock()
+	defer m.timerMx.Unlock()
+
+	m.random = r
+}
+
+// UseIDs draws the IDs of spawned actors, timers, schedules, subscriptions and
+// the correlation IDs of envelopes from r instead of generating random UUIDs,
+// so that a seeded r gives the same IDs on every run.
+func (m *Manager) UseIDs(r *rand.Rand) {
+	m.idMx.Lock()
+	defer m.idMx.Unlock()
+
+	m.ids = r
+}
+
+// newID returns a version 4 UUID drawn from the source set by UseIDs, if any.
+func (m *Manager) newID() uuid.UUID {
+	m.idMx.Lock()
+	defer m.idMx.Unlock()
+
+	if m.ids == nil {
+		return uuid.New()
+	}
+
+	var id uuid.UUID
+	for i := range id {
+		id[i] = byte(m.ids.UintN(256))
+	}
+	id[6] = id[6]&0x0f | 0x40
+	id[8] = id[8]&0x3f | 0x80
+
+	return id
+}
+
+// NewKind registers the factory activating the actors of kind. A kind whose
+// only actors so far were spawned by other actors can still be registered.
 func (m *Manager) NewKind(kind string, factory actorFactory) error {
-	if _, ok := m.actors[kind]; ok {
+	m.kindMx.Lock()
+	defer m.kindMx.Unlock()
+
+	collection, ok := m.actors[kind]
+	if !ok {
+		m.actors[kind] = m.newCollection(factory)
+		return nil
+	}
+
+	collection.mx.Lock()
+	defer collection.mx.Unlock()
+
+	if collection.factory != nil {
 		return fmt.Errorf("kind is already registered")
 	}
 
-	collection := NewActorCollection(factory)
-	m.actors[kind] = collection
+	collection.factory = factory
 
 	return nil
 }
 
-func (m *Manager) Send(ctx context.Context, address model.Address, msg proto.Message, timeout time.Duration) error {
-	actorCollection, ok := m.actors[address.Kind]
+// newCollection creates the collection of a kind. The caller must hold
+// m.kindMx.
+func (m *Manager) newCollection(factory actorFactory) *ActorCollection {
+	collection := NewActorCollection(factory)
+	collection.manager = m
+	collection.clock = m.clock
+	collection.executor = m.executor
+
+	return collection
+}
+
+// collection returns the collection of kind.
+func (m *Manager) collection(kind string) (*ActorCollection, error) {
+	m.kindMx.RLock()
+	defer m.kindMx.RUnlock()
+
+	collection, ok := m.actors[kind]
 	if !ok {
-		return fmt.Errorf("kind %s is not registered", address.Kind)
+		return nil, fmt.Errorf("kind %s is not registered", kind)
+	}
+
+	return collection, nil
+}
+
+// Send delivers msg to the actor at address in an envelope built from ctx and
+// opts. When ctx is the context of a Receive call, the receiving actor becomes
+// the sender and the headers of its envelope are carried over.
+func (m *Manager) Send(ctx context.Context, address model.Address, msg proto.Message, timeout time.Duration, opts ...model.SendOption) error {
+	return m.send(ctx, &Delivery{
+		Address:  address,
+		Envelope: m.envelope(ctx, address, timeout, opts),
+		Message:  msg,
+	})
+}
+
+// Ask delivers msg to the actor at address like Send and lets it fill res with
+// its response. The actor must handle msg within timeout.
+func (m *Manager) Ask(ctx context.Context, address model.Address, msg proto.Message, res proto.Message, timeout time.Duration, opts ...model.SendOption) error {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	return m.send(ctx, &Delivery{
+		Address:  address,
+		Envelope: m.envelope(ctx, address, timeout, opts),
+		Message:  msg,
+		Response: res,
+	})
+}
+
+func (m *Manager) envelope(ctx context.Context, address model.Address, timeout time.Duration, opts []model.SendOption) *message.Envelope {
+	deadline := m.clock.Now().Add(timeout)
+	if ctxDeadline, ok := ctx.Deadline(); ok && ctxDeadline.Before(deadline) {
+		deadline = ctxDeadline
+	}
+
+	envelope := &message.Envelope{
+		CorrelationID: m.newID().String(),
+		Recipient:     address.Message(),
+		Deadline:      timestamppb.New(deadline),
+		Headers:       make(map[string]string),
+	}
+
+	if incoming, ok := model.EnvelopeFromContext(ctx); ok {
+		envelope.Sender = incoming.Recipient
+		maps.Copy(envelope.Headers, incoming.Headers)
+	}
+
+	for _, opt := range opts {
+		opt(envelope)
+	}
+
+	if envelope.ReplyTo == nil {
+		envelope.ReplyTo = envelope.Sender
+	}
+
+	return envelope
+}
+
+// Actor returns the actor at address, activating it if it is not running.
+func (m *Manager) Actor(address model.Address) (model.Actor, error) {
+	actorCollection, err := m.collection(address.Kind)
+	if err != nil {
+		return nil, err
 	}
 
 	actor := actorCollection.Get(address)
+	if actor == nil {
+		return nil, fmt.Errorf("%w: %s/%s", model.ErrNotActive, address.Kind, address.ID)
+	}
+
+	return actor, nil
+}
+
+// deliver hands d.Message to the actor at d.Address through the receive side of
+// its interceptors. An actor that panics while receiving it is stopped, with
+// ReasonCrashed, and the panic is returned to the sender as an ErrCrashed.
+func (m *Manager) deliver(ctx context.Context, d *Delivery) (err error) {
+	address := d.Address
+
+	collection, err := m.collection(address.Kind)
+	if err != nil {
+		return err
+	}
+
+	actor := collection.Get(address)
+	if actor == nil {
+		return fmt.Errorf("%w: %s/%s", model.ErrNotActive, address.Kind, address.ID)
+	}
+
+	collection.begin(address)
+	defer collection.end(address)
+
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("%w: %s/%s: %v", ErrCrashed, address.Kind, address.ID, r)
+			slog.Error("actor crashed",
+				"actor_kind", address.Kind,
+				"actor_id", address.ID,
+				"correlation_id", d.Envelope.CorrelationID,
+				"error", err,
+			)
+
+			m.executor.Go(func() { m.crashed(address) })
+		}
+	}()
 
-	slog.Info("sending message to actor",
-		"recipient_kind", actor.GetKind(),
-		"recipient_id", actor.GetID(),
-	)
+	ctx = model.WithEnvelope(ctx, d.Envelope)
+	ctx = model.WithActorContext(ctx, &actorContext{manager: m, self: address, envelope: d.Envelope})
 
-	return actor.Receive(ctx, msg, nil)
+	return m.receiveChain(address.Kind)(ctx, actor, d)
 }
@@ -7,10 +7,13 @@ import (
 	"time"
 
 	"github.com/gnarloqgames/ga-actor-poc/internal/actor"
+	"github.com/gnarloqgames/ga-actor-poc/internal/clock"
 	"github.com/gnarloqgames/ga-actor-poc/internal/model"
 	"github.com/gnarloqgames/ga-actor-poc/message"
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/wrapperspb"
 )
 
 func TestGetInventory(t *testing.T) {
@@ -19,7 +22,7 @@ func TestGetInventory(t *testing.T) {
 		ID:   uuid.New(),
 	}
 
-	ctx := context.WithValue(context.Background(), model.KeyID, uuid.New())
+	ctx := model.WithActorContext(context.Background(), NewManager().Context(model.Address{Kind: "inventory", ID: uuid.New()}))
 	existingInventory := actor.InventoryActorFactory(ctx)
 	test2ID := createdAddress.Hash()
 
@@ -72,6 +75,9 @@ func TestGetInventory(t *testing.T) {
 
 func TestSend(t *testing.T) {
 	manager := NewManager()
+	err := manager.NewKind("inventory", actor.InventoryActorFactory)
+	require.NoError(t, err)
+
 	address := model.Address{
 		Kind: "inventory",
 		ID:   uuid.New(),
@@ -82,7 +88,133 @@ func TestSend(t *testing.T) {
 		Duration: "10s",
 	}
 
-	err := manager.Send(context.Background(), address, request, 10*time.Second)
+	err = manager.Send(context.Background(), address, request, 10*time.Second)
 
 	require.NoError(t, err)
 }
+
+func TestAsk(t *testing.T) {
+	manager := NewManager()
+	err := manager.NewKind("inventory", actor.InventoryActorFactory)
+	require.NoError(t, err)
+
+	address := model.Address{
+		Kind: "inventory",
+		ID:   uuid.New(),
+	}
+
+	request := &message.BuildRequest{
+		TraceID:  "trace",
+		Name:     "test",
+		Duration: "10s",
+	}
+	response := &message.BuildResponse{}
+
+	err = manager.Ask(context.Background(), address, request, response, 10*time.Second)
+
+	require.NoError(t, err)
+	require.Equal(t, "trace", response.TraceID)
+	require.Equal(t, request.ID, response.Response)
+}
+
+// relay records the envelope of every message and the ID of the actor
+// receiving it, and forwards it to next if set, unless that actor is next.
+func relay(m *Manager, next *model.Address, envelopes chan *message.Envelope, ids chan uuid.UUID) func(ctx context.Context, msg proto.Message, res proto.Message) error {
+	return func(ctx context.Context, msg proto.Message, res proto.Message) error {
+		envelope, _ := model.EnvelopeFromContext(ctx)
+		self := selfID(ctx)
+		envelopes <- envelope
+		ids <- self
+
+		if next == nil || next.ID == self {
+			return nil
+		}
+
+		return m.Send(ctx, *next, msg, time.Second)
+	}
+}
+
+func TestEnvelope(t *testing.T) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	sender := model.Address{Kind: "player", ID: uuid.New()}
+	replyTo := model.Address{Kind: "mailbox", ID: uuid.New()}
+
+	tests := []struct {
+		label               string
+		opts                []model.SendOption
+		expectedSender      *message.Address
+		expectedReplyTo     *message.Address
+		expectedCorrelation string
+		expectedHeaders     map[string]string
+	}{
+		{
+			label:           "bare",
+			expectedHeaders: map[string]string{},
+		},
+		{
+			label:               "sender",
+			opts:                []model.SendOption{model.WithSender(sender), model.WithCorrelationID("request-1")},
+			expectedSender:      sender.Message(),
+			expectedReplyTo:     sender.Message(),
+			expectedCorrelation: "request-1",
+			expectedHeaders:     map[string]string{},
+		},
+		{
+			label:           "reply to",
+			opts:            []model.SendOption{model.WithSender(sender), model.WithReplyTo(replyTo), model.WithHeader("trace", "abc")},
+			expectedSender:  sender.Message(),
+			expectedReplyTo: replyTo.Message(),
+			expectedHeaders: map[string]string{"trace": "abc"},
+		},
+	}
+
+	for _, tt := range tests {
+		tf := func(t *testing.T) {
+			manager := NewManagerWithClock(clock.NewFake(now))
+			envelopes := make(chan *message.Envelope, 1)
+			ids := make(chan uuid.UUID, 1)
+			newTestKind(t, manager, "relay", testActor{receive: relay(manager, nil, envelopes, ids)})
+
+			address := model.Address{Kind: "relay", ID: uuid.New()}
+			err := manager.Send(context.Background(), address, wrapperspb.String("hello"), time.Minute, tt.opts...)
+			require.NoError(t, err)
+
+			envelope := <-envelopes
+			require.Equal(t, address.ID, <-ids)
+			require.True(t, proto.Equal(address.Message(), envelope.Recipient))
+			require.True(t, proto.Equal(tt.expectedSender, envelope.Sender))
+			require.True(t, proto.Equal(tt.expectedReplyTo, envelope.ReplyTo))
+			require.Equal(t, now.Add(time.Minute), envelope.Deadline.AsTime())
+			require.Equal(t, tt.expectedHeaders, envelope.Headers)
+			if tt.expectedCorrelation != "" {
+				require.Equal(t, tt.expectedCorrelation, envelope.CorrelationID)
+			} else {
+				require.NotEmpty(t, envelope.CorrelationID)
+			}
+		}
+
+		t.Run(tt.label, tf)
+	}
+}
+
+func TestEnvelopeRelay(t *testing.T) {
+	manager := NewManager()
+	envelopes := make(chan *message.Envelope, 2)
+	ids := make(chan uuid.UUID, 2)
+	last := model.Address{Kind: "relay", ID: uuid.New()}
+	newTestKind(t, manager, "relay", testActor{receive: relay(manager, &last, envelopes, ids)})
+
+	first := model.Address{Kind: "relay", ID: uuid.New()}
+	err := manager.Send(context.Background(), first, wrapperspb.String("hello"), time.Second, model.WithHeader("trace", "abc"))
+	require.NoError(t, err)
+
+	incoming := <-envelopes
+	relayed := <-envelopes
+	require.Equal(t, first.ID, <-ids)
+	require.Equal(t, last.ID, <-ids)
+
+	require.True(t, proto.Equal(first.Message(), relayed.Sender))
+	require.True(t, proto.Equal(first.Message(), relayed.ReplyTo))
+	require.Equal(t, map[string]string{"trace": "abc"}, relayed.Headers)
+	require.NotEqual(t, incoming.CorrelationID, relayed.CorrelationID)
+}
@@ -0,0 +1,170 @@
+package manager
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/gnarloqgames/ga-actor-poc/internal/clock"
+	"github.com/gnarloqgames/ga-actor-poc/internal/model"
+	"github.com/google/uuid"
+	"google.golang.org/protobuf/proto"
+)
+
+type MissedRunPolicy string
+
+const (
+	AttributeScheduleID string = "schedule_id"
+	AttributeRuns       string = "runs"
+
+	// MissedRunSkip delivers a single message however many occurrences
+	// passed since the last run.
+	MissedRunSkip MissedRunPolicy = "skip"
+	// MissedRunCatchUp delivers one message per occurrence that passed
+	// since the last run.
+	MissedRunCatchUp MissedRunPolicy = "catch_up"
+)
+
+type ScheduleOptions struct {
+	// Jitter delays every run by a random duration below it.
+	Jitter     time.Duration
+	MissedRuns MissedRunPolicy
+}
+
+type recurring struct {
+	id       uuid.UUID
+	owner    model.Address
+	schedule Schedule
+	msg      proto.Message
+	options  ScheduleOptions
+
+	due   time.Time
+	timer clock.Timer
+}
+
+func (r *recurring) attributes() []any {
+	return []any{
+		AttributeScheduleID, r.id.String(),
+		AttributeOwnerKind, r.owner.Kind,
+		AttributeOwnerID, r.owner.ID.String(),
+	}
+}
+
+// runs returns how many occurrences are due at now and the first occurrence
+// after them. The next occurrence is zero, ending the schedule, once the
+// schedule stops moving forward.
+func (r *recurring) runs(now time.Time) (int, time.Time) {
+	runs := 0
+	next := r.due
+
+	for !next.IsZero() && !next.After(now) {
+		runs++
+
+		following := r.schedule.Next(next)
+		if !following.After(next) {
+			following = time.Time{}
+		}
+		next = following
+	}
+
+	if r.options.MissedRuns != MissedRunCatchUp {
+		runs = min(runs, 1)
+	}
+
+	return runs, next
+}
+
+// Schedule delivers msg to owner through Send at every occurrence of schedule
+// until the schedule is cancelled. Only the address is kept, so an owner that
+// is not active is activated by Send when a run fires.
+func (m *Manager) Schedule(owner model.Address, schedule Schedule, msg proto.Message, options ScheduleOptions) (uuid.UUID, error) {
+	if interval, ok := schedule.(intervalSchedule); ok && interval.interval <= 0 {
+		return uuid.Nil, fmt.Errorf("schedule interval must be positive, got %s", interval.interval)
+	}
+
+	now := m.clock.Now()
+	due := schedule.Next(now)
+	if due.IsZero() {
+		return uuid.Nil, fmt.Errorf("schedule never fires")
+	}
+	if !due.After(now) {
+		return uuid.Nil, fmt.Errorf("schedule does not move forward")
+	}
+
+	r := &recurring{
+		id:       m.newID(),
+		owner:    owner,
+		schedule: schedule,
+		msg:      msg,
+		options:  options,
+
+		due: due,
+	}
+
+	m.timerMx.Lock()
+	defer m.timerMx.Unlock()
+
+	m.schedules[r.id] = r
+	m.armSchedule(r)
+
+	slog.Info("schedule registered", append(r.attributes(), AttributeFireAt, due.String())...)
+
+	return r.id, nil
+}
+
+// CancelSchedule stops all future runs of a schedule.
+func (m *Manager) CancelSchedule(id uuid.UUID) error {
+	m.timerMx.Lock()
+	defer m.timerMx.Unlock()
+
+	r, ok := m.schedules[id]
+	if !ok {
+		return fmt.Errorf("schedule %s not found", id)
+	}
+
+	r.timer.Stop()
+	delete(m.schedules, id)
+
+	slog.Info("schedule cancelled", r.attributes()...)
+
+	return nil
+}
+
+// armSchedule registers the next run of r with the clock. The caller
+// must hold m.timerMx.
+func (m *Manager) armSchedule(r *recurring) {
+	delay := r.due.Sub(m.clock.Now())
+	if r.options.Jitter > 0 {
+		delay += time.Duration(m.random.Int64N(int64(r.options.Jitter)))
+	}
+
+	r.timer = m.clock.AfterFunc(delay, func() {
+		m.runSchedule(r)
+	})
+}
+
+func (m *Manager) runSchedule(r *recurring) {
+	m.timerMx.Lock()
+	if _, ok := m.schedules[r.id]; !ok {
+		m.timerMx.Unlock()
+		return
+	}
+
+	runs, next := r.runs(m.clock.Now())
+	r.due = next
+	if next.IsZero() {
+		delete(m.schedules, r.id)
+	} else {
+		m.armSchedule(r)
+	}
+	m.timerMx.Unlock()
+
+	slog.Info("schedule fired", append(r.attributes(), AttributeRuns, runs)...)
+
+	for i := 0; i < runs; i++ {
+		if err := m.Send(context.Background(), r.owner, proto.Clone(r.msg), TimerDeliveryTimeout); err != nil {
+			slog.Error("failed to deliver scheduled message", append(r.attributes(), "error", err)...)
+		}
+	}
+}
@@ -0,0 +1,166 @@
+package manager
+
+import (
+	"testing"
+	"time"
+
+	"github.com/gnarloqgames/ga-actor-poc/internal/clock"
+	"github.com/gnarloqgames/ga-actor-poc/internal/model"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+func TestSchedule(t *testing.T) {
+	manager, received := newRecordingManager(t, clock.Real)
+	owner := model.Address{Kind: "recorder", ID: uuid.New()}
+
+	id, err := manager.Schedule(owner, Every(30*time.Millisecond), wrapperspb.String("tick"), ScheduleOptions{
+		Jitter: 5 * time.Millisecond,
+	})
+	require.NoError(t, err)
+
+	expectMessage(t, received, "tick", time.Second)
+	expectMessage(t, received, "tick", time.Second)
+
+	require.NoError(t, manager.CancelSchedule(id))
+	require.Error(t, manager.CancelSchedule(id))
+
+	// A run may already have been in flight when the schedule was cancelled.
+	select {
+	case <-received:
+	case <-time.After(10 * time.Millisecond):
+	}
+	expectNoMessage(t, received, 100*time.Millisecond)
+}
+
+func TestScheduleRuns(t *testing.T) {
+	due := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		label        string
+		policy       MissedRunPolicy
+		now          time.Time
+		expectedRuns int
+		expectedNext time.Time
+	}{
+		{
+			label:        "on time",
+			policy:       MissedRunSkip,
+			now:          due,
+			expectedRuns: 1,
+			expectedNext: due.Add(time.Hour),
+		},
+		{
+			label:        "skip missed",
+			policy:       MissedRunSkip,
+			now:          due.Add(150 * time.Minute),
+			expectedRuns: 1,
+			expectedNext: due.Add(3 * time.Hour),
+		},
+		{
+			label:        "catch up missed",
+			policy:       MissedRunCatchUp,
+			now:          due.Add(150 * time.Minute),
+			expectedRuns: 3,
+			expectedNext: due.Add(3 * time.Hour),
+		},
+		{
+			label:        "early",
+			policy:       MissedRunCatchUp,
+			now:          due.Add(-time.Minute),
+			expectedRuns: 0,
+			expectedNext: due,
+		},
+	}
+
+	for _, tt := range tests {
+		tf := func(t *testing.T) {
+			r := &recurring{
+				schedule: Every(time.Hour),
+				options:  ScheduleOptions{MissedRuns: tt.policy},
+				due:      due,
+			}
+
+			runs, next := r.runs(tt.now)
+
+			require.Equal(t, tt.expectedRuns, runs)
+			require.Equal(t, tt.expectedNext, next)
+		}
+
+		t.Run(tt.label, tf)
+	}
+}
+
+// stuckSchedule returns the same occurrence forever.
+type stuckSchedule struct {
+	at time.Time
+}
+
+func (s stuckSchedule) Next(after time.Time) time.Time {
+	return s.at
+}
+
+func TestScheduleRunsStuck(t *testing.T) {
+	due := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	r := &recurring{
+		schedule: stuckSchedule{at: due},
+		options:  ScheduleOptions{MissedRuns: MissedRunCatchUp},
+		due:      due,
+	}
+
+	runs, next := r.runs(due.Add(time.Hour))
+
+	require.Equal(t, 1, runs)
+	require.True(t, next.IsZero())
+}
+
+func TestScheduleInvalid(t *testing.T) {
+	fake := clock.NewFake(time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC))
+	manager, _ := newRecordingManager(t, fake)
+	owner := model.Address{Kind: "recorder", ID: uuid.New()}
+
+	tests := []struct {
+		label    string
+		schedule Schedule
+	}{
+		{label: "zero interval", schedule: Every(0)},
+		{label: "negative interval", schedule: Every(-time.Second)},
+		{label: "stuck", schedule: stuckSchedule{at: fake.Now()}},
+	}
+
+	for _, tt := range tests {
+		tf := func(t *testing.T) {
+			_, err := manager.Schedule(owner, tt.schedule, wrapperspb.String("tick"), ScheduleOptions{})
+			require.Error(t, err)
+		}
+
+		t.Run(tt.label, tf)
+	}
+}
+
+func TestScheduleNeverFires(t *testing.T) {
+	manager, _ := newRecordingManager(t, clock.Real)
+	schedule, err := ParseCron("0 0 31 2 *")
+	require.NoError(t, err)
+
+	_, err = manager.Schedule(model.Address{Kind: "recorder", ID: uuid.New()}, schedule, wrapperspb.String("never"), ScheduleOptions{})
+	require.Error(t, err)
+}
+
+func TestScheduleFakeClock(t *testing.T) {
+	fake := clock.NewFake(time.Date(2024, 6, 1, 0, 30, 0, 0, time.UTC))
+	manager, received := newRecordingManager(t, fake)
+	schedule, err := ParseCron("0 */6 * * *")
+	require.NoError(t, err)
+
+	_, err = manager.Schedule(model.Address{Kind: "recorder", ID: uuid.New()}, schedule, wrapperspb.String("tick"), ScheduleOptions{})
+	require.NoError(t, err)
+
+	fake.Advance(5 * time.Hour)
+	require.Empty(t, received)
+
+	fake.Advance(19 * time.Hour)
+	require.Len(t, received, 4)
+	require.Equal(t, time.Date(2024, 6, 2, 0, 30, 0, 0, time.UTC), fake.Now())
+}
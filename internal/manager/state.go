@@ -0,0 +1,58 @@
+package manager
+
+import (
+	"fmt"
+
+	"github.com/gnarloqgames/ga-actor-poc/internal/model"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/anypb"
+)
+
+// UseStateStore makes the manager keep the state actors save in store instead
+// of in memory.
+func (m *Manager) UseStateStore(store StateStore) {
+	m.stateMx.Lock()
+	defer m.stateMx.Unlock()
+
+	m.stateStore = store
+}
+
+// SaveState replaces the saved state of the actor at address.
+func (m *Manager) SaveState(address model.Address, state proto.Message) error {
+	payload, err := anypb.New(state)
+	if err != nil {
+		return fmt.Errorf("failed to wrap state: %w", err)
+	}
+
+	m.stateMx.Lock()
+	store := m.stateStore
+	m.stateMx.Unlock()
+
+	if err := store.Save(address, payload); err != nil {
+		return fmt.Errorf("failed to save state: %w", err)
+	}
+
+	return nil
+}
+
+// LoadState fills state with the state last saved for the actor at address.
+// It reports false if the actor never saved any.
+func (m *Manager) LoadState(address model.Address, state proto.Message) (bool, error) {
+	m.stateMx.Lock()
+	store := m.stateStore
+	m.stateMx.Unlock()
+
+	payload, ok, err := store.Load(address)
+	if err != nil {
+		return false, fmt.Errorf("failed to load state: %w", err)
+	}
+	if !ok {
+		return false, nil
+	}
+
+	if err := payload.UnmarshalTo(state); err != nil {
+		return false, fmt.Errorf("failed to unwrap state: %w", err)
+	}
+
+	return true, nil
+}
@@ -0,0 +1,55 @@
+package manager
+
+import (
+	"testing"
+
+	"github.com/gnarloqgames/ga-actor-poc/internal/model"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/anypb"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+func TestSaveState(t *testing.T) {
+	manager := NewManager()
+	actx := manager.Context(model.Address{Kind: "recorder", ID: uuid.New()})
+
+	ok, err := actx.LoadState(&wrapperspb.StringValue{})
+	require.NoError(t, err)
+	require.False(t, ok)
+
+	require.NoError(t, actx.SaveState(wrapperspb.String("first")))
+	require.NoError(t, actx.SaveState(wrapperspb.String("second")))
+
+	state := &wrapperspb.StringValue{}
+	ok, err = actx.LoadState(state)
+	require.NoError(t, err)
+	require.True(t, ok)
+	require.Equal(t, "second", state.Value)
+
+	other := manager.Context(model.Address{Kind: "recorder", ID: uuid.New()})
+	ok, err = other.LoadState(&wrapperspb.StringValue{})
+	require.NoError(t, err)
+	require.False(t, ok)
+}
+
+func TestFileStateStore(t *testing.T) {
+	store, err := NewFileStateStore(t.TempDir())
+	require.NoError(t, err)
+
+	address := model.Address{Kind: "recorder", ID: uuid.New()}
+	_, ok, err := store.Load(address)
+	require.NoError(t, err)
+	require.False(t, ok)
+
+	state, err := anypb.New(wrapperspb.String("saved"))
+	require.NoError(t, err)
+	require.NoError(t, store.Save(address, state))
+	require.NoError(t, store.Save(address, state))
+
+	loaded, ok, err := store.Load(address)
+	require.NoError(t, err)
+	require.True(t, ok)
+	require.True(t, proto.Equal(state, loaded))
+}
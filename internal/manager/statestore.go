@@ -0,0 +1,113 @@
+package manager
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/gnarloqgames/ga-actor-poc/internal/model"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/anypb"
+)
+
+// StateStore persists the state actors save, so that it survives passivation
+// and process restarts.
+type StateStore interface {
+	Save(address model.Address, state *anypb.Any) error
+	// Load returns the state last saved for address. It reports false if
+	// nothing was saved.
+	Load(address model.Address) (*anypb.Any, bool, error)
+}
+
+type MemoryStateStore struct {
+	mx *sync.Mutex
+
+	states map[model.Address]*anypb.Any
+}
+
+func NewMemoryStateStore() *MemoryStateStore {
+	return &MemoryStateStore{
+		mx: &sync.Mutex{},
+
+		states: make(map[model.Address]*anypb.Any),
+	}
+}
+
+func (s *MemoryStateStore) Save(address model.Address, state *anypb.Any) error {
+	s.mx.Lock()
+	defer s.mx.Unlock()
+
+	s.states[address] = proto.Clone(state).(*anypb.Any)
+
+	return nil
+}
+
+func (s *MemoryStateStore) Load(address model.Address) (*anypb.Any, bool, error) {
+	s.mx.Lock()
+	defer s.mx.Unlock()
+
+	state, ok := s.states[address]
+	if !ok {
+		return nil, false, nil
+	}
+
+	return proto.Clone(state).(*anypb.Any), true, nil
+}
+
+const stateFileExtension = ".state"
+
+// FileStateStore keeps the state of every actor in its own file, inside a
+// directory per kind.
+type FileStateStore struct {
+	dir string
+}
+
+func NewFileStateStore(dir string) (*FileStateStore, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create state directory: %w", err)
+	}
+
+	return &FileStateStore{dir: dir}, nil
+}
+
+func (s *FileStateStore) path(address model.Address) string {
+	return filepath.Join(s.dir, address.Kind, address.ID.String()+stateFileExtension)
+}
+
+func (s *FileStateStore) Save(address model.Address, state *anypb.Any) error {
+	data, err := proto.Marshal(state)
+	if err != nil {
+		return fmt.Errorf("failed to marshal state: %w", err)
+	}
+
+	path := s.path(address)
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("failed to create state directory: %w", err)
+	}
+
+	if err := writeFile(path, data); err != nil {
+		return fmt.Errorf("failed to save state file: %w", err)
+	}
+
+	return nil
+}
+
+func (s *FileStateStore) Load(address model.Address) (*anypb.Any, bool, error) {
+	data, err := os.ReadFile(s.path(address))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to read state file: %w", err)
+	}
+
+	state := &anypb.Any{}
+	if err := proto.Unmarshal(data, state); err != nil {
+		return nil, false, fmt.Errorf("failed to unmarshal state of %s/%s: %w", address.Kind, address.ID, err)
+	}
+
+	return state, true, nil
+}
@@ -0,0 +1,160 @@
+package manager
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/gnarloqgames/ga-actor-poc/internal/model"
+)
+
+// KindStats describes the actors of a kind at one point in time.
+type KindStats struct {
+	Kind string
+	// Active is the number of running actors.
+	Active int
+	// Activations and Passivations count the actors that joined and left the
+	// collection since the kind was created.
+	Activations  uint64
+	Passivations uint64
+	// Mailbox is the number of messages sent to actors of the kind that they
+	// have not finished handling.
+	Mailbox int64
+	// Queues sums the queue lengths reported by the running actors of the
+	// kind that implement model.QueueReporter, by queue name.
+	Queues map[string]int
+}
+
+// Stats describes the actors and timers of a manager at one point in time.
+type Stats struct {
+	// Kinds is sorted by kind.
+	Kinds []KindStats
+	// Timers is the number of durable timers that have not fired.
+	Timers int
+	// Schedules is the number of running recurring schedules.
+	Schedules int
+}
+
+// Stats returns the current counts of the manager.
+func (m *Manager) Stats() Stats {
+	m.kindMx.RLock()
+	kinds := make([]KindStats, 0, len(m.actors))
+	collections := make([]*ActorCollection, 0, len(m.actors))
+	for kind, collection := range m.actors {
+		kinds = append(kinds, KindStats{Kind: kind})
+		collections = append(collections, collection)
+	}
+	m.kindMx.RUnlock()
+
+	for i, collection := range collections {
+		kinds[i].Activations = collection.activations.Load()
+		kinds[i].Passivations = collection.passivations.Load()
+		kinds[i].Mailbox = collection.inFlight.Load()
+		kinds[i].Queues = make(map[string]int)
+
+		actors := collection.Actors()
+		kinds[i].Active = len(actors)
+		for _, actor := range actors {
+			reporter, ok := actor.(model.QueueReporter)
+			if !ok {
+				continue
+			}
+
+			for name, length := range reporter.QueueLengths() {
+				kinds[i].Queues[name] += length
+			}
+		}
+	}
+
+	slices.SortFunc(kinds, func(a, b KindStats) int {
+		return strings.Compare(a.Kind, b.Kind)
+	})
+
+	m.timerMx.Lock()
+	defer m.timerMx.Unlock()
+
+	return Stats{
+		Kinds:     kinds,
+		Timers:    len(m.timers),
+		Schedules: len(m.schedules),
+	}
+}
+
+// ActorStats describes a running actor at one point in time.
+type ActorStats struct {
+	Address model.Address
+	// Mailbox is the number of messages sent to the actor that it has not
+	// finished handling.
+	Mailbox int64
+	// LastActivity is when the actor was activated or last started or
+	// finished handling a message, on the clock of the manager.
+	LastActivity time.Time
+}
+
+// Kinds returns the kinds the manager knows, sorted, including those only
+// made of spawned actors.
+func (m *Manager) Kinds() []string {
+	m.kindMx.RLock()
+	defer m.kindMx.RUnlock()
+
+	kinds := make([]string, 0, len(m.actors))
+	for kind := range m.actors {
+		kinds = append(kinds, kind)
+	}
+	slices.Sort(kinds)
+
+	return kinds
+}
+
+// ActorStats returns the running actors of kind, sorted by ID.
+func (m *Manager) ActorStats(kind string) ([]ActorStats, error) {
+	collection, err := m.collection(kind)
+	if err != nil {
+		return nil, err
+	}
+
+	collection.mx.Lock()
+	stats := make([]ActorStats, 0, len(collection.actors))
+	for id := range collection.actors {
+		stats = append(stats, collection.stats(model.Address{Kind: kind, ID: id}))
+	}
+	collection.mx.Unlock()
+
+	slices.SortFunc(stats, func(a, b ActorStats) int {
+		return strings.Compare(a.Address.ID.String(), b.Address.ID.String())
+	})
+
+	return stats, nil
+}
+
+// Lookup returns the running actor at address and its stats without
+// activating it.
+func (m *Manager) Lookup(address model.Address) (model.Actor, ActorStats, error) {
+	collection, err := m.collection(address.Kind)
+	if err != nil {
+		return nil, ActorStats{}, err
+	}
+
+	collection.mx.Lock()
+	defer collection.mx.Unlock()
+
+	actor, ok := collection.actors[address.ID]
+	if !ok {
+		return nil, ActorStats{}, fmt.Errorf("%w: %s/%s", model.ErrNotActive, address.Kind, address.ID)
+	}
+
+	return actor, collection.stats(address), nil
+}
+
+// stats returns the stats of the actor at address. The caller must hold
+// i.mx.
+func (i *ActorCollection) stats(address model.Address) ActorStats {
+	stats := ActorStats{Address: address}
+	if a, ok := i.activity[address.ID]; ok {
+		stats.Mailbox = a.inFlight
+		stats.LastActivity = a.last
+	}
+
+	return stats
+}
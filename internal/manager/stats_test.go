@@ -0,0 +1,117 @@
+package manager
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/gnarloqgames/ga-actor-poc/internal/actor"
+	"github.com/gnarloqgames/ga-actor-poc/internal/clock"
+	"github.com/gnarloqgames/ga-actor-poc/internal/model"
+	"github.com/gnarloqgames/ga-actor-poc/message"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+func TestStats(t *testing.T) {
+	manager, destroyed, _ := newFamilyManager(t)
+	err := manager.NewKind("inventory", actor.InventoryActorFactory)
+	require.NoError(t, err)
+
+	parent := model.Address{Kind: "family", ID: uuid.New()}
+	_, err = manager.Actor(parent)
+	require.NoError(t, err)
+	child, err := manager.Context(parent).Spawn("family")
+	require.NoError(t, err)
+	require.NoError(t, manager.Stop(context.Background(), child))
+	<-destroyed
+
+	inventory := model.Address{Kind: "inventory", ID: uuid.New()}
+	for _, name := range []string{"farm", "mill", "well"} {
+		err = manager.Ask(context.Background(), inventory, &message.BuildRequest{Name: name, Duration: "1m"}, &message.BuildResponse{}, time.Second)
+		require.NoError(t, err)
+	}
+	defer func() {
+		require.NoError(t, manager.Stop(context.Background(), inventory))
+	}()
+
+	_, err = manager.ScheduleAt(parent, time.Now().Add(time.Hour), &message.Terminated{})
+	require.NoError(t, err)
+
+	// The first build leaves the queue once the inventory starts it, with a
+	// durable timer waking the inventory when it is due.
+	var stats Stats
+	require.Eventually(t, func() bool {
+		stats = manager.Stats()
+		return stats.Kinds[1].Queues[actor.QueueBuild] == 2 && stats.Timers == 2
+	}, time.Second, time.Millisecond)
+	require.Equal(t, 0, stats.Schedules)
+	require.Equal(t, []KindStats{
+		{
+			Kind:         "family",
+			Active:       1,
+			Activations:  2,
+			Passivations: 1,
+			Queues:       map[string]int{},
+		},
+		{
+			Kind:        "inventory",
+			Active:      1,
+			Activations: 1,
+			Queues:      map[string]int{actor.QueueBuild: 2, actor.QueueCraft: 0},
+		},
+		{
+			Kind:        actor.TimerKind,
+			Active:      1,
+			Activations: 1,
+			Queues:      map[string]int{},
+		},
+	}, stats.Kinds)
+}
+
+func TestActorStats(t *testing.T) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	fake := clock.NewFake(now)
+	manager := NewManagerWithClock(fake)
+	received := make(chan struct{})
+	release := make(chan struct{})
+	// The actor holds every message until release is closed.
+	newTestKind(t, manager, "blocking", testActor{
+		receive: func(ctx context.Context, msg proto.Message, res proto.Message) error {
+			received <- struct{}{}
+			<-release
+
+			return nil
+		},
+	})
+
+	address := model.Address{Kind: "blocking", ID: uuid.New()}
+	_, err := manager.Actor(address)
+	require.NoError(t, err)
+
+	fake.Advance(time.Minute)
+	done := make(chan error)
+	go func() {
+		done <- manager.Send(context.Background(), address, wrapperspb.String("hello"), time.Second)
+	}()
+	<-received
+
+	_, stats, err := manager.Lookup(address)
+	require.NoError(t, err)
+	require.Equal(t, ActorStats{Address: address, Mailbox: 1, LastActivity: now.Add(time.Minute)}, stats)
+	require.Equal(t, int64(1), manager.Stats().Kinds[0].Mailbox)
+
+	fake.Advance(time.Minute)
+	close(release)
+	require.NoError(t, <-done)
+
+	all, err := manager.ActorStats("blocking")
+	require.NoError(t, err)
+	require.Equal(t, []ActorStats{{Address: address, LastActivity: now.Add(2 * time.Minute)}}, all)
+	require.Equal(t, []string{"blocking"}, manager.Kinds())
+
+	_, err = manager.ActorStats("castle")
+	require.Error(t, err)
+}
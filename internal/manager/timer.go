@@ -0,0 +1,186 @@
+package manager
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/gnarloqgames/ga-actor-poc/internal/clock"
+	"github.com/gnarloqgames/ga-actor-poc/internal/model"
+	"github.com/gnarloqgames/ga-actor-poc/message"
+	"github.com/google/uuid"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/anypb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const (
+	AttributeTimerID   string = "timer_id"
+	AttributeOwnerKind string = "owner_kind"
+	AttributeOwnerID   string = "owner_id"
+	AttributeFireAt    string = "fire_at"
+
+	TimerDeliveryTimeout = 10 * time.Second
+)
+
+func timerAttributes(timer *message.DurableTimer) []any {
+	return []any{
+		AttributeTimerID, timer.ID,
+		AttributeOwnerKind, timer.Owner.GetKind(),
+		AttributeOwnerID, timer.Owner.GetID(),
+		AttributeFireAt, timer.FireAt.AsTime().String(),
+	}
+}
+
+// UseTimerStore makes the manager persist durable timers in store and
+// schedules every timer already in it. Timers whose fire time has passed
+// while the process was down fire immediately.
+func (m *Manager) UseTimerStore(store TimerStore) error {
+	timers, err := store.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load timers: %w", err)
+	}
+
+	m.timerMx.Lock()
+	defer m.timerMx.Unlock()
+
+	m.timerStore = store
+
+	for _, timer := range timers {
+		if err := m.startTimer(timer); err != nil {
+			slog.Error("failed to restore timer", append(timerAttributes(timer), "error", err)...)
+			continue
+		}
+
+		slog.Info("restored timer", timerAttributes(timer)...)
+	}
+
+	return nil
+}
+
+// ScheduleAt persists a timer that delivers msg to owner through Send once
+// fireAt has passed. A timer is removed from the store as soon as its message
+// has been handed to the owner, whether or not the owner accepted it.
+func (m *Manager) ScheduleAt(owner model.Address, fireAt time.Time, msg proto.Message) (uuid.UUID, error) {
+	return m.scheduleAt(owner, fireAt, msg, nil)
+}
+
+// scheduleAt is ScheduleAt for a message whose envelope gets headers, such as
+// the headers of the message an actor was handling when it set the timer.
+func (m *Manager) scheduleAt(owner model.Address, fireAt time.Time, msg proto.Message, headers map[string]string) (uuid.UUID, error) {
+	payload, err := anypb.New(msg)
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("failed to wrap timer message: %w", err)
+	}
+
+	id := m.newID()
+	timer := &message.DurableTimer{
+		ID:      id.String(),
+		Owner:   owner.Message(),
+		FireAt:  timestamppb.New(fireAt),
+		Message: payload,
+		Headers: headers,
+	}
+
+	m.timerMx.Lock()
+	defer m.timerMx.Unlock()
+
+	if err := m.timerStore.Save(timer); err != nil {
+		return uuid.Nil, fmt.Errorf("failed to save timer: %w", err)
+	}
+
+	if err := m.startTimer(timer); err != nil {
+		return uuid.Nil, err
+	}
+
+	slog.Info("scheduled timer", timerAttributes(timer)...)
+
+	return id, nil
+}
+
+// CancelTimer stops and forgets a durable timer. Cancelling a timer that has
+// already fired has no effect.
+func (m *Manager) CancelTimer(id uuid.UUID) error {
+	m.timerMx.Lock()
+	defer m.timerMx.Unlock()
+
+	timer, ok := m.timers[id]
+	if !ok {
+		return nil
+	}
+
+	timer.Stop()
+	delete(m.timers, id)
+
+	if err := m.timerStore.Delete(id); err != nil {
+		return fmt.Errorf("failed to delete timer: %w", err)
+	}
+
+	return nil
+}
+
+// pendingTimer is the clock timer of a durable timer that has not fired yet.
+// Timers are compared by pointer to tell a timer from its replacement.
+type pendingTimer struct {
+	clock.Timer
+}
+
+// startTimer registers timer with the clock. The caller must hold
+// m.timerMx.
+func (m *Manager) startTimer(timer *message.DurableTimer) error {
+	id, err := uuid.Parse(timer.ID)
+	if err != nil {
+		return fmt.Errorf("invalid timer id: %w", err)
+	}
+
+	owner, err := model.AddressFromMessage(timer.Owner)
+	if err != nil {
+		return err
+	}
+
+	msg, err := timer.Message.UnmarshalNew()
+	if err != nil {
+		return fmt.Errorf("failed to unwrap timer message: %w", err)
+	}
+
+	if existing, ok := m.timers[id]; ok {
+		existing.Stop()
+	}
+
+	pending := &pendingTimer{}
+	pending.Timer = m.clock.AfterFunc(timer.FireAt.AsTime().Sub(m.clock.Now()), func() {
+		m.fireTimer(id, pending, owner, msg, timer.Headers, timerAttributes(timer))
+	})
+	m.timers[id] = pending
+
+	return nil
+}
+
+// fireTimer delivers the message of the pending timer id. It forgets the timer
+// unless it was replaced while firing, in which case the entry and the stored
+// timer belong to its replacement.
+func (m *Manager) fireTimer(id uuid.UUID, pending *pendingTimer, owner model.Address, msg proto.Message, headers map[string]string, attributes []any) {
+	slog.Info("timer fired", attributes...)
+
+	opts := make([]model.SendOption, 0, len(headers))
+	for key, value := range headers {
+		opts = append(opts, model.WithHeader(key, value))
+	}
+
+	if err := m.Send(context.Background(), owner, msg, TimerDeliveryTimeout, opts...); err != nil {
+		slog.Error("failed to deliver timer message", append(attributes, "error", err)...)
+	}
+
+	m.timerMx.Lock()
+	defer m.timerMx.Unlock()
+
+	if m.timers[id] != pending {
+		return
+	}
+	delete(m.timers, id)
+
+	if err := m.timerStore.Delete(id); err != nil {
+		slog.Error("failed to delete fired timer", append(attributes, "error", err)...)
+	}
+}
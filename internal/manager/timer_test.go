@@ -0,0 +1,187 @@
+package manager
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/gnarloqgames/ga-actor-poc/internal/clock"
+	"github.com/gnarloqgames/ga-actor-poc/internal/model"
+	"github.com/gnarloqgames/ga-actor-poc/message"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/anypb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+func newRecordingManager(t *testing.T, c clock.Clock) (*Manager, chan proto.Message) {
+	t.Helper()
+
+	received := make(chan proto.Message, 10)
+	manager := NewManagerWithClock(c)
+	newTestKind(t, manager, "recorder", testActor{
+		receive: func(ctx context.Context, msg proto.Message, res proto.Message) error {
+			received <- msg
+			return nil
+		},
+	})
+
+	return manager, received
+}
+
+func expectMessage(t *testing.T, received chan proto.Message, expected string, within time.Duration) {
+	t.Helper()
+
+	select {
+	case msg := <-received:
+		require.Equal(t, expected, msg.(*wrapperspb.StringValue).Value)
+	case <-time.After(within):
+		t.Fatalf("message %q not received within %s", expected, within)
+	}
+}
+
+func expectNoMessage(t *testing.T, received chan proto.Message, within time.Duration) {
+	t.Helper()
+
+	select {
+	case msg := <-received:
+		t.Fatalf("unexpected message %v", msg)
+	case <-time.After(within):
+	}
+}
+
+func TestScheduleAt(t *testing.T) {
+	manager, received := newRecordingManager(t, clock.Real)
+	store := NewMemoryTimerStore()
+	require.NoError(t, manager.UseTimerStore(store))
+
+	owner := model.Address{Kind: "recorder", ID: uuid.New()}
+
+	_, err := manager.ScheduleAt(owner, time.Now().Add(20*time.Millisecond), wrapperspb.String("fired"))
+	require.NoError(t, err)
+	cancelled, err := manager.ScheduleAt(owner, time.Now().Add(20*time.Millisecond), wrapperspb.String("cancelled"))
+	require.NoError(t, err)
+
+	timers, err := store.Load()
+	require.NoError(t, err)
+	require.Len(t, timers, 2)
+
+	require.NoError(t, manager.CancelTimer(cancelled))
+
+	expectMessage(t, received, "fired", time.Second)
+	expectNoMessage(t, received, 50*time.Millisecond)
+
+	timers, err = store.Load()
+	require.NoError(t, err)
+	require.Empty(t, timers)
+}
+
+func TestTimerReplacedWhileFiring(t *testing.T) {
+	fake := clock.NewFake(time.Now())
+	manager, received := newRecordingManager(t, fake)
+
+	owner := model.Address{Kind: "recorder", ID: uuid.New()}
+	id, err := manager.ScheduleAt(owner, fake.Now().Add(time.Hour), wrapperspb.String("first"))
+	require.NoError(t, err)
+
+	// Fill the buffer of the recorder so that delivering the timer blocks.
+	for range cap(received) {
+		received <- wrapperspb.String("filler")
+	}
+
+	fired := make(chan struct{})
+	go func() {
+		defer close(fired)
+		fake.Advance(time.Hour)
+	}()
+	require.Eventually(t, func() bool {
+		stats := manager.Stats()
+		return len(stats.Kinds) == 1 && stats.Kinds[0].Mailbox > 0
+	}, time.Second, time.Millisecond)
+
+	payload, err := anypb.New(wrapperspb.String("replacement"))
+	require.NoError(t, err)
+	store := NewMemoryTimerStore()
+	require.NoError(t, store.Save(&message.DurableTimer{
+		ID:      id.String(),
+		Owner:   owner.Message(),
+		FireAt:  timestamppb.New(fake.Now().Add(time.Hour)),
+		Message: payload,
+	}))
+	require.NoError(t, manager.UseTimerStore(store))
+
+	for range cap(received) {
+		<-received
+	}
+	expectMessage(t, received, "first", time.Second)
+	<-fired
+
+	timers, err := store.Load()
+	require.NoError(t, err)
+	require.Len(t, timers, 1, "the replacement is still stored")
+	require.Equal(t, 1, manager.Stats().Timers)
+
+	fake.Advance(time.Hour)
+	expectMessage(t, received, "replacement", time.Second)
+}
+
+func TestUseTimerStore(t *testing.T) {
+	store, err := NewFileTimerStore(t.TempDir())
+	require.NoError(t, err)
+
+	owner := model.Address{Kind: "recorder", ID: uuid.New()}
+	for label, fireAt := range map[string]time.Time{
+		"overdue": time.Now().Add(-time.Hour),
+		"future":  time.Now().Add(50 * time.Millisecond),
+	} {
+		payload, err := anypb.New(wrapperspb.String(label))
+		require.NoError(t, err)
+
+		err = store.Save(&message.DurableTimer{
+			ID:      uuid.New().String(),
+			Owner:   owner.Message(),
+			FireAt:  timestamppb.New(fireAt),
+			Message: payload,
+		})
+		require.NoError(t, err)
+	}
+
+	manager, received := newRecordingManager(t, clock.Real)
+	require.NoError(t, manager.UseTimerStore(store))
+
+	expectMessage(t, received, "overdue", 20*time.Millisecond)
+	expectMessage(t, received, "future", time.Second)
+
+	require.Eventually(t, func() bool {
+		timers, err := store.Load()
+		return err == nil && len(timers) == 0
+	}, time.Second, time.Millisecond)
+}
+
+func TestFileTimerStore(t *testing.T) {
+	store, err := NewFileTimerStore(t.TempDir())
+	require.NoError(t, err)
+
+	id := uuid.New()
+	timer := &message.DurableTimer{
+		ID:     id.String(),
+		Owner:  model.Address{Kind: "recorder", ID: uuid.New()}.Message(),
+		FireAt: timestamppb.Now(),
+	}
+	require.NoError(t, store.Save(timer))
+	require.NoError(t, store.Save(timer))
+
+	timers, err := store.Load()
+	require.NoError(t, err)
+	require.Len(t, timers, 1)
+	require.True(t, proto.Equal(timer, timers[0]))
+
+	require.NoError(t, store.Delete(id))
+	require.NoError(t, store.Delete(id))
+
+	timers, err = store.Load()
+	require.NoError(t, err)
+	require.Empty(t, timers)
+}
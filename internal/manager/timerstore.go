@@ -0,0 +1,155 @@
+package manager
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/gnarloqgames/ga-actor-poc/message"
+	"github.com/google/uuid"
+	"google.golang.org/protobuf/proto"
+)
+
+// TimerStore persists durable timers so they survive process restarts.
+type TimerStore interface {
+	Save(timer *message.DurableTimer) error
+	Delete(id uuid.UUID) error
+	Load() ([]*message.DurableTimer, error)
+}
+
+type MemoryTimerStore struct {
+	mx *sync.Mutex
+
+	timers map[string]*message.DurableTimer
+}
+
+func NewMemoryTimerStore() *MemoryTimerStore {
+	return &MemoryTimerStore{
+		mx: &sync.Mutex{},
+
+		timers: make(map[string]*message.DurableTimer),
+	}
+}
+
+func (s *MemoryTimerStore) Save(timer *message.DurableTimer) error {
+	s.mx.Lock()
+	defer s.mx.Unlock()
+
+	s.timers[timer.ID] = proto.Clone(timer).(*message.DurableTimer)
+
+	return nil
+}
+
+func (s *MemoryTimerStore) Delete(id uuid.UUID) error {
+	s.mx.Lock()
+	defer s.mx.Unlock()
+
+	delete(s.timers, id.String())
+
+	return nil
+}
+
+func (s *MemoryTimerStore) Load() ([]*message.DurableTimer, error) {
+	s.mx.Lock()
+	defer s.mx.Unlock()
+
+	timers := make([]*message.DurableTimer, 0, len(s.timers))
+	for _, timer := range s.timers {
+		timers = append(timers, proto.Clone(timer).(*message.DurableTimer))
+	}
+
+	return timers, nil
+}
+
+const timerFileExtension = ".timer"
+
+// FileTimerStore keeps every timer in its own file inside a directory.
+type FileTimerStore struct {
+	dir string
+}
+
+func NewFileTimerStore(dir string) (*FileTimerStore, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create timer directory: %w", err)
+	}
+
+	return &FileTimerStore{dir: dir}, nil
+}
+
+func (s *FileTimerStore) path(id string) string {
+	return filepath.Join(s.dir, id+timerFileExtension)
+}
+
+func (s *FileTimerStore) Save(timer *message.DurableTimer) error {
+	data, err := proto.Marshal(timer)
+	if err != nil {
+		return fmt.Errorf("failed to marshal timer: %w", err)
+	}
+
+	if err := writeFile(s.path(timer.ID), data); err != nil {
+		return fmt.Errorf("failed to save timer file: %w", err)
+	}
+
+	return nil
+}
+
+// writeFile writes data to a temporary file next to path first and renames it,
+// so a crash never leaves a partially written file behind.
+func writeFile(path string, data []byte) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), "tmp-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name()) //nolint
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close() //nolint
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+
+	return os.Rename(tmp.Name(), path)
+}
+
+func (s *FileTimerStore) Delete(id uuid.UUID) error {
+	err := os.Remove(s.path(id.String()))
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return fmt.Errorf("failed to delete timer file: %w", err)
+	}
+
+	return nil
+}
+
+func (s *FileTimerStore) Load() ([]*message.DurableTimer, error) {
+	entries, err := os.ReadDir(s.dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read timer directory: %w", err)
+	}
+
+	timers := make([]*message.DurableTimer, 0, len(entries))
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), timerFileExtension) {
+			continue
+		}
+
+		data, err := os.ReadFile(filepath.Join(s.dir, entry.Name()))
+		if err != nil {
+			return nil, fmt.Errorf("failed to read timer file: %w", err)
+		}
+
+		timer := &message.DurableTimer{}
+		if err := proto.Unmarshal(data, timer); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal timer %s: %w", entry.Name(), err)
+		}
+
+		timers = append(timers, timer)
+	}
+
+	return timers, nil
+}
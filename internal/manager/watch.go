@@ -0,0 +1,163 @@
+package manager
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"slices"
+	"time"
+
+	"github.com/gnarloqgames/ga-actor-poc/internal/model"
+	"github.com/gnarloqgames/ga-actor-poc/message"
+)
+
+const (
+	AttributeWatcherKind string = "watcher_kind"
+	AttributeWatcherID   string = "watcher_id"
+	AttributeTargetKind  string = "target_kind"
+	AttributeTargetID    string = "target_id"
+	AttributeReason      string = "reason"
+
+	// ReasonStopped is given when an actor was stopped with Stop, itself or
+	// through one of its ancestors.
+	ReasonStopped string = "stopped"
+	// ReasonCrashed is given when an actor panicked while receiving a
+	// message.
+	ReasonCrashed string = "crashed"
+	// ReasonPassivated is given when an actor, or the parent of an actor,
+	// was passivated.
+	ReasonPassivated string = "passivated"
+	// ReasonNotActive is given to watchers of an actor that was not running
+	// when they started watching it.
+	ReasonNotActive string = "not_active"
+
+	TerminatedDeliveryTimeout = 10 * time.Second
+)
+
+var ErrCrashed = errors.New("actor crashed")
+
+func watchAttributes(watcher model.Address, target model.Address) []any {
+	return []any{
+		AttributeWatcherKind, watcher.Kind,
+		AttributeWatcherID, watcher.ID.String(),
+		AttributeTargetKind, target.Kind,
+		AttributeTargetID, target.ID.String(),
+	}
+}
+
+// Watch makes the manager send a message.Terminated to watcher once the actor
+// at target stops, whatever the reason. A watcher is told exactly once per
+// call, right away if target is not running, with the reason it was last
+// terminated for, or ReasonNotActive if it never ran or was terminated before
+// the last MaxTerminationReasons actors of its kind. Watching a target twice
+// has no further effect.
+func (m *Manager) Watch(watcher model.Address, target model.Address) error {
+	collection, err := m.collection(target.Kind)
+	if err != nil {
+		return err
+	}
+
+	m.watchMx.Lock()
+	defer m.watchMx.Unlock()
+
+	if reason, ok := collection.reason(target); ok {
+		m.executor.Go(func() { m.notifyWatcher(watcher, target, reason) })
+
+		return nil
+	}
+
+	if slices.Contains(m.watchers[target], watcher) {
+		return nil
+	}
+
+	m.watchers[target] = append(m.watchers[target], watcher)
+	m.watching[watcher] = append(m.watching[watcher], target)
+
+	slog.Info("watching actor", watchAttributes(watcher, target)...)
+
+	return nil
+}
+
+// Unwatch stops the notification set up by Watch, unless it has already been
+// sent.
+func (m *Manager) Unwatch(watcher model.Address, target model.Address) {
+	m.watchMx.Lock()
+	defer m.watchMx.Unlock()
+
+	m.unwatch(watcher, target)
+}
+
+// unwatch removes the watch of watcher on target. The caller must hold
+// m.watchMx.
+func (m *Manager) unwatch(watcher model.Address, target model.Address) {
+	m.watchers[target] = slices.DeleteFunc(m.watchers[target], func(a model.Address) bool { return a == watcher })
+	if len(m.watchers[target]) == 0 {
+		delete(m.watchers, target)
+	}
+
+	m.watching[watcher] = slices.DeleteFunc(m.watching[watcher], func(a model.Address) bool { return a == target })
+	if len(m.watching[watcher]) == 0 {
+		delete(m.watching, watcher)
+	}
+}
+
+// terminated notifies and forgets the watchers of an actor that has just been
+// removed from its collection, and drops the watches the actor held itself. It
+// returns the watchers notified.
+func (m *Manager) terminated(address model.Address, reason string) []model.Address {
+	m.watchMx.Lock()
+	defer m.watchMx.Unlock()
+
+	watchers := m.notify(address, reason)
+
+	for _, target := range slices.Clone(m.watching[address]) {
+		m.unwatch(address, target)
+	}
+
+	return watchers
+}
+
+// notifyWatchers notifies and forgets the watchers of an actor that has just
+// been removed from its collection, keeping the watches the actor holds.
+func (m *Manager) notifyWatchers(address model.Address, reason string) {
+	m.watchMx.Lock()
+	defer m.watchMx.Unlock()
+
+	m.notify(address, reason)
+}
+
+// notify notifies and forgets the watchers of address and returns them. The
+// caller must hold m.watchMx.
+func (m *Manager) notify(address model.Address, reason string) []model.Address {
+	watchers := slices.Clone(m.watchers[address])
+	for _, watcher := range watchers {
+		m.unwatch(watcher, address)
+		m.executor.Go(func() { m.notifyWatcher(watcher, address, reason) })
+	}
+
+	return watchers
+}
+
+func (m *Manager) notifyWatcher(watcher model.Address, target model.Address, reason string) {
+	terminated := &message.Terminated{
+		Actor:  target.Message(),
+		Reason: reason,
+	}
+
+	err := m.Send(context.Background(), watcher, terminated, TerminatedDeliveryTimeout, model.WithSender(target))
+	if err != nil {
+		slog.Error("failed to notify watcher of terminated actor", append(watchAttributes(watcher, target), AttributeReason, reason, "error", err)...)
+	}
+}
+
+// crashed stops an actor that panicked, unless it was stopped meanwhile.
+func (m *Manager) crashed(address model.Address) {
+	err := m.stop(context.Background(), address, ReasonCrashed, true)
+	if err != nil && !errors.Is(err, model.ErrNotActive) {
+		slog.Error("failed to stop crashed actor",
+			"actor_kind", address.Kind,
+			"actor_id", address.ID,
+			"error", err,
+		)
+	}
+}
@@ -0,0 +1,192 @@
+package manager
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/gnarloqgames/ga-actor-poc/internal/model"
+	"github.com/gnarloqgames/ga-actor-poc/message"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+func expectTerminated(t *testing.T, terminated chan *message.Terminated, target model.Address, reason string) {
+	t.Helper()
+
+	select {
+	case msg := <-terminated:
+		require.Equal(t, target.Message().ID, msg.Actor.ID)
+		require.Equal(t, reason, msg.Reason)
+	case <-time.After(time.Second):
+		t.Fatalf("termination of %s was not delivered", target.ID)
+	}
+
+	select {
+	case msg := <-terminated:
+		t.Fatalf("unexpected termination of %s", msg.Actor.ID)
+	case <-time.After(10 * time.Millisecond):
+	}
+}
+
+func TestWatch(t *testing.T) {
+	tests := []struct {
+		label          string
+		run            func(t *testing.T, manager *Manager, watcher model.Address, target model.Address)
+		expectedReason string
+	}{
+		{
+			label: "stopped",
+			run: func(t *testing.T, manager *Manager, watcher model.Address, target model.Address) {
+				require.NoError(t, manager.Watch(watcher, target))
+				require.NoError(t, manager.Watch(watcher, target))
+				require.NoError(t, manager.Stop(context.Background(), target))
+			},
+			expectedReason: ReasonStopped,
+		},
+		{
+			label: "already stopped",
+			run: func(t *testing.T, manager *Manager, watcher model.Address, target model.Address) {
+				require.NoError(t, manager.Stop(context.Background(), target))
+				require.NoError(t, manager.Watch(watcher, target))
+			},
+			expectedReason: ReasonStopped,
+		},
+		{
+			label: "already crashed",
+			run: func(t *testing.T, manager *Manager, watcher model.Address, target model.Address) {
+				err := manager.Send(context.Background(), target, wrapperspb.String("boom"), time.Second)
+				require.ErrorIs(t, err, ErrCrashed)
+
+				require.Eventually(t, func() bool {
+					_, ok := manager.actors["family"].Lookup(target)
+					return !ok
+				}, time.Second, time.Millisecond)
+				require.NoError(t, manager.Watch(watcher, target))
+			},
+			expectedReason: ReasonCrashed,
+		},
+		{
+			label: "crashed",
+			run: func(t *testing.T, manager *Manager, watcher model.Address, target model.Address) {
+				require.NoError(t, manager.Watch(watcher, target))
+
+				err := manager.Send(context.Background(), target, wrapperspb.String("boom"), time.Second)
+				require.ErrorIs(t, err, ErrCrashed)
+			},
+			expectedReason: ReasonCrashed,
+		},
+	}
+
+	for _, tt := range tests {
+		tf := func(t *testing.T) {
+			manager, _, terminated := newFamilyManager(t)
+			watcher := model.Address{Kind: "family", ID: uuid.New()}
+			target := model.Address{Kind: "family", ID: uuid.New()}
+			_, err := manager.Actor(target)
+			require.NoError(t, err)
+
+			tt.run(t, manager, watcher, target)
+
+			expectTerminated(t, terminated, target, tt.expectedReason)
+			_, ok := manager.actors["family"].Lookup(target)
+			require.False(t, ok)
+		}
+
+		t.Run(tt.label, tf)
+	}
+}
+
+func TestWatchReason(t *testing.T) {
+	manager, _, terminated := newFamilyManager(t)
+	watcher := model.Address{Kind: "family", ID: uuid.New()}
+	target := model.Address{Kind: "family", ID: uuid.New()}
+
+	require.NoError(t, manager.Watch(watcher, target))
+	expectTerminated(t, terminated, target, ReasonNotActive)
+
+	_, err := manager.Actor(target)
+	require.NoError(t, err)
+	require.NoError(t, manager.Stop(context.Background(), target))
+
+	// Activating the target again forgets why it stopped.
+	_, err = manager.Actor(target)
+	require.NoError(t, err)
+	require.NoError(t, manager.Watch(watcher, target))
+	require.NoError(t, manager.Stop(context.Background(), target))
+	expectTerminated(t, terminated, target, ReasonStopped)
+}
+
+func TestWatchReasonLimit(t *testing.T) {
+	manager, _, terminated := newFamilyManager(t)
+	manager.actors["family"].maxReasons = 2
+	watcher := model.Address{Kind: "family", ID: uuid.New()}
+
+	targets := make([]model.Address, 3)
+	for i := range targets {
+		targets[i] = model.Address{Kind: "family", ID: uuid.New()}
+		_, err := manager.Actor(targets[i])
+		require.NoError(t, err)
+		require.NoError(t, manager.Stop(context.Background(), targets[i]))
+	}
+
+	// Only the reasons of the last two targets are kept.
+	require.Len(t, manager.actors["family"].reasons, 2)
+
+	require.NoError(t, manager.Watch(watcher, targets[0]))
+	expectTerminated(t, terminated, targets[0], ReasonNotActive)
+
+	require.NoError(t, manager.Watch(watcher, targets[2]))
+	expectTerminated(t, terminated, targets[2], ReasonStopped)
+}
+
+func TestUnwatch(t *testing.T) {
+	manager, _, terminated := newFamilyManager(t)
+	watcher := model.Address{Kind: "family", ID: uuid.New()}
+	target := model.Address{Kind: "family", ID: uuid.New()}
+	_, err := manager.Actor(target)
+	require.NoError(t, err)
+
+	require.NoError(t, manager.Watch(watcher, target))
+	manager.Unwatch(watcher, target)
+	require.NoError(t, manager.Stop(context.Background(), target))
+
+	select {
+	case msg := <-terminated:
+		t.Fatalf("unexpected termination of %s", msg.Actor.ID)
+	case <-time.After(10 * time.Millisecond):
+	}
+}
+
+func TestWatchChild(t *testing.T) {
+	manager, _, terminated := newFamilyManager(t)
+	parent := model.Address{Kind: "family", ID: uuid.New()}
+	_, err := manager.Actor(parent)
+	require.NoError(t, err)
+
+	child, err := manager.Spawn(parent, "family")
+	require.NoError(t, err)
+	require.NoError(t, manager.Context(parent).Watch(child))
+	require.NoError(t, manager.Stop(context.Background(), child))
+
+	expectTerminated(t, terminated, child, ReasonStopped)
+}
+
+func TestWatcherStops(t *testing.T) {
+	manager, _, _ := newFamilyManager(t)
+	watcher := model.Address{Kind: "family", ID: uuid.New()}
+	target := model.Address{Kind: "family", ID: uuid.New()}
+	for _, address := range []model.Address{watcher, target} {
+		_, err := manager.Actor(address)
+		require.NoError(t, err)
+	}
+
+	require.NoError(t, manager.Watch(watcher, target))
+	require.NoError(t, manager.Stop(context.Background(), watcher))
+
+	manager.watchMx.Lock()
+	defer manager.watchMx.Unlock()
+	require.Empty(t, manager.watchers)
+	require.Empty(t, manager.watching)
+}
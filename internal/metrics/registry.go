@@ -0,0 +1,366 @@
+package metrics
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// ContentType is the content type of the Prometheus text exposition format.
+const ContentType string = "text/plain; version=0.0.4; charset=utf-8"
+
+// DefaultBuckets are the upper bounds, in seconds, of the buckets of latency
+// histograms.
+var DefaultBuckets = []float64{0.0005, 0.001, 0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10}
+
+type metricType string
+
+const (
+	typeCounter   metricType = "counter"
+	typeGauge     metricType = "gauge"
+	typeHistogram metricType = "histogram"
+)
+
+// Registry holds metric families and writes them in the Prometheus text
+// format. It is an http.Handler serving that format.
+type Registry struct {
+	mx *sync.Mutex
+
+	families   []*family
+	collectors []func()
+}
+
+func NewRegistry() *Registry {
+	return &Registry{
+		mx: &sync.Mutex{},
+
+		families:   make([]*family, 0),
+		collectors: make([]func(), 0),
+	}
+}
+
+// OnCollect registers fn to run before every scrape, to refresh gauges that
+// are read from somewhere else rather than updated as things happen.
+func (r *Registry) OnCollect(fn func()) {
+	r.mx.Lock()
+	defer r.mx.Unlock()
+
+	r.collectors = append(r.collectors, fn)
+}
+
+// Counter registers a family of counters partitioned by labels.
+func (r *Registry) Counter(name string, help string, labels ...string) *CounterVec {
+	return &CounterVec{family: r.register(name, help, typeCounter, nil, labels)}
+}
+
+// Gauge registers a family of gauges partitioned by labels.
+func (r *Registry) Gauge(name string, help string, labels ...string) *GaugeVec {
+	return &GaugeVec{family: r.register(name, help, typeGauge, nil, labels)}
+}
+
+// Histogram registers a family of histograms partitioned by labels, with
+// buckets sorted in increasing order of their upper bound.
+func (r *Registry) Histogram(name string, help string, buckets []float64, labels ...string) *HistogramVec {
+	return &HistogramVec{family: r.register(name, help, typeHistogram, slices.Clone(buckets), labels)}
+}
+
+func (r *Registry) register(name string, help string, t metricType, buckets []float64, labels []string) *family {
+	r.mx.Lock()
+	defer r.mx.Unlock()
+
+	if slices.ContainsFunc(r.families, func(f *family) bool { return f.name == name }) {
+		panic(fmt.Sprintf("metric %s is already registered", name))
+	}
+
+	f := &family{
+		mx:      &sync.Mutex{},
+		name:    name,
+		help:    help,
+		typ:     t,
+		labels:  labels,
+		buckets: buckets,
+		series:  make(map[string]*series),
+	}
+	r.families = append(r.families, f)
+
+	return f
+}
+
+// Write runs the collectors and writes every family to w, sorted by name.
+// Writes hold the registry from the first collector to the last family, so
+// overlapping scrapes never see gauges another one is refilling. Collectors
+// must therefore not register metrics.
+func (r *Registry) Write(w io.Writer) error {
+	r.mx.Lock()
+	defer r.mx.Unlock()
+
+	for _, collect := range r.collectors {
+		collect()
+	}
+
+	families := slices.Clone(r.families)
+	slices.SortFunc(families, func(a, b *family) int {
+		return strings.Compare(a.name, b.name)
+	})
+
+	buf := bufio.NewWriter(w)
+	for _, f := range families {
+		f.write(buf)
+	}
+
+	return buf.Flush()
+}
+
+func (r *Registry) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	w.Header().Set("Content-Type", ContentType)
+
+	if err := r.Write(w); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
+
+type family struct {
+	mx *sync.Mutex
+
+	name    string
+	help    string
+	typ     metricType
+	labels  []string
+	buckets []float64
+	series  map[string]*series
+}
+
+type series struct {
+	labels []string
+
+	value float64
+	// counts holds the number of observations of each bucket, not
+	// cumulated, followed by those above the last bucket.
+	counts []uint64
+	sum    float64
+	count  uint64
+}
+
+// with returns the series of values, creating it. The caller must hold f.mx.
+func (f *family) with(values []string) *series {
+	if len(values) != len(f.labels) {
+		panic(fmt.Sprintf("metric %s has %d labels, got %d values", f.name, len(f.labels), len(values)))
+	}
+
+	key := strings.Join(values, "\xff")
+	s, ok := f.series[key]
+	if !ok {
+		s = &series{labels: slices.Clone(values)}
+		if f.typ == typeHistogram {
+			s.counts = make([]uint64, len(f.buckets)+1)
+		}
+		f.series[key] = s
+	}
+
+	return s
+}
+
+// lookup returns the series of values without creating it. The caller must
+// hold f.mx.
+func (f *family) lookup(values []string) (*series, bool) {
+	s, ok := f.series[strings.Join(values, "\xff")]
+
+	return s, ok
+}
+
+func (f *family) write(w *bufio.Writer) {
+	f.mx.Lock()
+	defer f.mx.Unlock()
+
+	fmt.Fprintf(w, "# HELP %s %s\n", f.name, escapeHelp(f.help))
+	fmt.Fprintf(w, "# TYPE %s %s\n", f.name, f.typ)
+
+	keys := make([]string, 0, len(f.series))
+	for key := range f.series {
+		keys = append(keys, key)
+	}
+	slices.Sort(keys)
+
+	for _, key := range keys {
+		s := f.series[key]
+		if f.typ != typeHistogram {
+			fmt.Fprintf(w, "%s%s %s\n", f.name, f.labelString(s.labels, ""), formatFloat(s.value))
+			continue
+		}
+
+		var cumulative uint64
+		for i, bound := range f.buckets {
+			cumulative += s.counts[i]
+			fmt.Fprintf(w, "%s_bucket%s %d\n", f.name, f.labelString(s.labels, formatFloat(bound)), cumulative)
+		}
+		fmt.Fprintf(w, "%s_bucket%s %d\n", f.name, f.labelString(s.labels, "+Inf"), s.count)
+		fmt.Fprintf(w, "%s_sum%s %s\n", f.name, f.labelString(s.labels, ""), formatFloat(s.sum))
+		fmt.Fprintf(w, "%s_count%s %d\n", f.name, f.labelString(s.labels, ""), s.count)
+	}
+}
+
+// labelString formats the labels of a series, with an le label for the
+// buckets of histograms.
+func (f *family) labelString(values []string, le string) string {
+	pairs := make([]string, 0, len(values)+1)
+	for i, value := range values {
+		pairs = append(pairs, fmt.Sprintf("%s=\"%s\"", f.labels[i], escapeLabel(value)))
+	}
+	if le != "" {
+		pairs = append(pairs, fmt.Sprintf("le=\"%s\"", le))
+	}
+
+	if len(pairs) == 0 {
+		return ""
+	}
+
+	return "{" + strings.Join(pairs, ",") + "}"
+}
+
+func formatFloat(v float64) string {
+	switch {
+	case math.IsInf(v, 1):
+		return "+Inf"
+	case math.IsInf(v, -1):
+		return "-Inf"
+	case math.IsNaN(v):
+		return "NaN"
+	}
+
+	return strconv.FormatFloat(v, 'g', -1, 64)
+}
+
+var (
+	labelEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+	helpEscaper  = strings.NewReplacer(`\`, `\\`, "\n", `\n`)
+)
+
+func escapeLabel(value string) string {
+	return labelEscaper.Replace(value)
+}
+
+func escapeHelp(help string) string {
+	return helpEscaper.Replace(help)
+}
+
+// CounterVec is a family of counters, which only go up.
+type CounterVec struct {
+	family *family
+}
+
+// Add adds delta, which must not be negative, to the counter of values.
+func (c *CounterVec) Add(delta float64, values ...string) {
+	if delta < 0 {
+		panic(fmt.Sprintf("counter %s cannot decrease", c.family.name))
+	}
+
+	c.family.mx.Lock()
+	defer c.family.mx.Unlock()
+
+	c.family.with(values).value += delta
+}
+
+// Inc adds one to the counter of values.
+func (c *CounterVec) Inc(values ...string) {
+	c.Add(1, values...)
+}
+
+// Set sets the counter of values to total, for counters kept elsewhere.
+func (c *CounterVec) Set(total float64, values ...string) {
+	c.family.mx.Lock()
+	defer c.family.mx.Unlock()
+
+	c.family.with(values).value = total
+}
+
+// Value returns the counter of values.
+func (c *CounterVec) Value(values ...string) float64 {
+	c.family.mx.Lock()
+	defer c.family.mx.Unlock()
+
+	s, ok := c.family.lookup(values)
+	if !ok {
+		return 0
+	}
+
+	return s.value
+}
+
+// GaugeVec is a family of gauges, which go up and down.
+type GaugeVec struct {
+	family *family
+}
+
+// Set sets the gauge of values to v.
+func (g *GaugeVec) Set(v float64, values ...string) {
+	g.family.mx.Lock()
+	defer g.family.mx.Unlock()
+
+	g.family.with(values).value = v
+}
+
+// Add adds delta to the gauge of values.
+func (g *GaugeVec) Add(delta float64, values ...string) {
+	g.family.mx.Lock()
+	defer g.family.mx.Unlock()
+
+	g.family.with(values).value += delta
+}
+
+// Value returns the gauge of values.
+func (g *GaugeVec) Value(values ...string) float64 {
+	g.family.mx.Lock()
+	defer g.family.mx.Unlock()
+
+	s, ok := g.family.lookup(values)
+	if !ok {
+		return 0
+	}
+
+	return s.value
+}
+
+// Reset forgets every gauge of the family, so that collectors setting them
+// again drop the label values that are gone.
+func (g *GaugeVec) Reset() {
+	g.family.mx.Lock()
+	defer g.family.mx.Unlock()
+
+	clear(g.family.series)
+}
+
+// HistogramVec is a family of histograms counting observations in buckets.
+type HistogramVec struct {
+	family *family
+}
+
+// Observe adds v to the histogram of values.
+func (h *HistogramVec) Observe(v float64, values ...string) {
+	h.family.mx.Lock()
+	defer h.family.mx.Unlock()
+
+	s := h.family.with(values)
+	i, _ := slices.BinarySearch(h.family.buckets, v)
+	s.counts[i]++
+	s.sum += v
+	s.count++
+}
+
+// Count returns the number of observations of the histogram of values.
+func (h *HistogramVec) Count(values ...string) uint64 {
+	h.family.mx.Lock()
+	defer h.family.mx.Unlock()
+
+	s, ok := h.family.lookup(values)
+	if !ok {
+		return 0
+	}
+
+	return s.count
+}
@@ -0,0 +1,160 @@
+package metrics
+
+import (
+	"bytes"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRegistryWrite(t *testing.T) {
+	tests := []struct {
+		label    string
+		record   func(r *Registry)
+		expected string
+	}{
+		{
+			label: "counter",
+			record: func(r *Registry) {
+				c := r.Counter("sent_total", "Sent messages.", "kind")
+				c.Inc("b")
+				c.Add(2, "a")
+				c.Inc("b")
+			},
+			expected: "# HELP sent_total Sent messages.\n" +
+				"# TYPE sent_total counter\n" +
+				"sent_total{kind=\"a\"} 2\n" +
+				"sent_total{kind=\"b\"} 2\n",
+		},
+		{
+			label: "gauge",
+			record: func(r *Registry) {
+				g := r.Gauge("depth", "Depth.\nIn messages.")
+				g.Set(3)
+				g.Add(-1.5)
+			},
+			expected: "# HELP depth Depth.\\nIn messages.\n" +
+				"# TYPE depth gauge\n" +
+				"depth 1.5\n",
+		},
+		{
+			label: "escaped label",
+			record: func(r *Registry) {
+				r.Gauge("names", "Names.", "name").Set(1, "a \"b\"\\c\n")
+			},
+			expected: "# HELP names Names.\n" +
+				"# TYPE names gauge\n" +
+				"names{name=\"a \\\"b\\\"\\\\c\\n\"} 1\n",
+		},
+		{
+			label: "histogram",
+			record: func(r *Registry) {
+				h := r.Histogram("latency", "Latency.", []float64{0.1, 1}, "kind")
+				h.Observe(0.05, "a")
+				h.Observe(0.1, "a")
+				h.Observe(0.5, "a")
+				h.Observe(2, "a")
+			},
+			expected: "# HELP latency Latency.\n" +
+				"# TYPE latency histogram\n" +
+				"latency_bucket{kind=\"a\",le=\"0.1\"} 2\n" +
+				"latency_bucket{kind=\"a\",le=\"1\"} 3\n" +
+				"latency_bucket{kind=\"a\",le=\"+Inf\"} 4\n" +
+				"latency_sum{kind=\"a\"} 2.65\n" +
+				"latency_count{kind=\"a\"} 4\n",
+		},
+		{
+			label: "reset",
+			record: func(r *Registry) {
+				g := r.Gauge("active", "Active.", "kind")
+				g.Set(1, "gone")
+				g.Reset()
+				g.Set(2, "kept")
+			},
+			expected: "# HELP active Active.\n" +
+				"# TYPE active gauge\n" +
+				"active{kind=\"kept\"} 2\n",
+		},
+	}
+
+	for _, tt := range tests {
+		tf := func(t *testing.T) {
+			r := NewRegistry()
+			tt.record(r)
+
+			buf := &bytes.Buffer{}
+			require.NoError(t, r.Write(buf))
+			require.Equal(t, tt.expected, buf.String())
+		}
+
+		t.Run(tt.label, tf)
+	}
+}
+
+func TestRegistryCollect(t *testing.T) {
+	r := NewRegistry()
+	g := r.Gauge("scrapes", "Scrapes.")
+	r.OnCollect(func() { g.Add(1) })
+
+	require.NoError(t, r.Write(&bytes.Buffer{}))
+	require.NoError(t, r.Write(&bytes.Buffer{}))
+	require.Equal(t, float64(2), g.Value())
+}
+
+func TestRegistryOverlappingScrapes(t *testing.T) {
+	r := NewRegistry()
+	g := r.Gauge("active", "Active.", "kind")
+
+	// The second scrape resets the gauge while the first one is about to
+	// write it out, unless scrapes run one at a time.
+	reset := make(chan struct{})
+	written := make(chan struct{})
+	calls := 0
+	r.OnCollect(func() {
+		calls++
+		g.Reset()
+		if calls == 2 {
+			close(reset)
+			select {
+			case <-written:
+			case <-time.After(100 * time.Millisecond):
+			}
+		}
+		g.Set(1, "a")
+		if calls == 1 {
+			select {
+			case <-reset:
+			case <-time.After(100 * time.Millisecond):
+			}
+		}
+	})
+
+	first := &bytes.Buffer{}
+	var wg sync.WaitGroup
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		defer close(written)
+		require.NoError(t, r.Write(first))
+	}()
+	go func() {
+		defer wg.Done()
+		time.Sleep(10 * time.Millisecond)
+		require.NoError(t, r.Write(&bytes.Buffer{}))
+	}()
+	wg.Wait()
+
+	require.Contains(t, first.String(), "active{kind=\"a\"} 1\n")
+}
+
+func TestRegistryMisuse(t *testing.T) {
+	r := NewRegistry()
+	c := r.Counter("sent_total", "Sent messages.", "kind")
+
+	require.Panics(t, func() { r.Gauge("sent_total", "Again.") })
+	require.Panics(t, func() { c.Inc() })
+	require.Panics(t, func() { c.Add(-1, "a") })
+	require.Zero(t, c.Value("a"))
+}
@@ -0,0 +1,137 @@
+package metrics
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/gnarloqgames/ga-actor-poc/internal/manager"
+	"github.com/gnarloqgames/ga-actor-poc/internal/model"
+	"google.golang.org/protobuf/proto"
+)
+
+// PathMetrics is the path NewServer serves metrics on.
+const PathMetrics string = "/metrics"
+
+const (
+	LabelKind    string = "kind"
+	LabelMessage string = "message"
+	LabelQueue   string = "queue"
+)
+
+// Runtime holds the metrics of the actors and timers of a manager.
+type Runtime struct {
+	Active       *GaugeVec
+	Activations  *CounterVec
+	Passivations *CounterVec
+	Mailbox      *GaugeVec
+	Queues       *GaugeVec
+	Timers       *GaugeVec
+	Schedules    *GaugeVec
+
+	Sent     *CounterVec
+	Received *CounterVec
+	Failed   *CounterVec
+	Handler  *HistogramVec
+}
+
+// Instrument registers the runtime metrics of m in r. Messages are counted and
+// timed by an interceptor added to m, while actor, queue and timer counts are
+// read from m.Stats on every scrape, so actors need not do anything to be
+// measured. Their queues are reported by those implementing
+// model.QueueReporter.
+func Instrument(m *manager.Manager, r *Registry) *Runtime {
+	rt := &Runtime{
+		Active:       r.Gauge("actor_active", "Number of running actors.", LabelKind),
+		Activations:  r.Counter("actor_activations_total", "Number of actors activated or spawned.", LabelKind),
+		Passivations: r.Counter("actor_passivations_total", "Number of actors stopped or passivated.", LabelKind),
+		Mailbox:      r.Gauge("actor_mailbox_depth", "Number of messages sent to actors and not handled yet.", LabelKind),
+		Queues:       r.Gauge("actor_queue_length", "Number of items queued by actors.", LabelKind, LabelQueue),
+		Timers:       r.Gauge("actor_timers_pending", "Number of durable timers scheduled through the manager that have not fired."),
+		Schedules:    r.Gauge("actor_schedules_active", "Number of recurring schedules."),
+
+		Sent:     r.Counter("actor_messages_sent_total", "Number of messages sent to actors.", LabelKind, LabelMessage),
+		Received: r.Counter("actor_messages_received_total", "Number of messages received by actors.", LabelKind, LabelMessage),
+		Failed:   r.Counter("actor_messages_failed_total", "Number of messages that could not be delivered or whose handler failed.", LabelKind, LabelMessage),
+		Handler:  r.Histogram("actor_handler_duration_seconds", "Time actors take to handle messages.", DefaultBuckets, LabelKind, LabelMessage),
+	}
+
+	m.Use(rt.Interceptor())
+	r.OnCollect(func() { rt.Collect(m.Stats()) })
+
+	return rt
+}
+
+// Interceptor counts the messages sent, received and failed and times their
+// handlers.
+func (rt *Runtime) Interceptor() manager.Interceptor {
+	return manager.Interceptor{
+		Send: func(next manager.SendHandler) manager.SendHandler {
+			return func(ctx context.Context, d *manager.Delivery) error {
+				name := messageName(d.Message)
+				rt.Sent.Inc(d.Address.Kind, name)
+
+				err := next(ctx, d)
+				if err != nil {
+					rt.Failed.Inc(d.Address.Kind, name)
+				}
+
+				return err
+			}
+		},
+		Receive: func(next manager.ReceiveHandler) manager.ReceiveHandler {
+			return func(ctx context.Context, actor model.Actor, d *manager.Delivery) error {
+				name := messageName(d.Message)
+				rt.Received.Inc(d.Address.Kind, name)
+
+				start := time.Now()
+				defer func() {
+					rt.Handler.Observe(time.Since(start).Seconds(), d.Address.Kind, name)
+				}()
+
+				return next(ctx, actor, d)
+			}
+		},
+	}
+}
+
+// Collect sets the gauges and counters read from the manager to stats.
+func (rt *Runtime) Collect(stats manager.Stats) {
+	rt.Active.Reset()
+	rt.Mailbox.Reset()
+	rt.Queues.Reset()
+
+	for _, kind := range stats.Kinds {
+		rt.Active.Set(float64(kind.Active), kind.Kind)
+		rt.Activations.Set(float64(kind.Activations), kind.Kind)
+		rt.Passivations.Set(float64(kind.Passivations), kind.Kind)
+		rt.Mailbox.Set(float64(kind.Mailbox), kind.Kind)
+
+		for queue, length := range kind.Queues {
+			rt.Queues.Set(float64(length), kind.Kind, queue)
+		}
+	}
+
+	rt.Timers.Set(float64(stats.Timers))
+	rt.Schedules.Set(float64(stats.Schedules))
+}
+
+// NewServer returns a server exposing r on PathMetrics at addr.
+func NewServer(addr string, r *Registry) *http.Server {
+	mux := http.NewServeMux()
+	mux.Handle(PathMetrics, r)
+
+	return &http.Server{
+		Addr:              addr,
+		Handler:           mux,
+		ReadHeaderTimeout: 5 * time.Second,
+	}
+}
+
+func messageName(msg proto.Message) string {
+	if msg == nil {
+		return ""
+	}
+
+	return string(msg.ProtoReflect().Descriptor().FullName())
+}
@@ -0,0 +1,115 @@
+package metrics
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gnarloqgames/ga-actor-poc/internal/actor"
+	"github.com/gnarloqgames/ga-actor-poc/internal/model"
+	"github.com/gnarloqgames/ga-actor-poc/internal/testkit"
+	"github.com/gnarloqgames/ga-actor-poc/message"
+	"github.com/gnarloqgames/ga-actor-poc/message/actorpb"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+func scrape(t *testing.T, r *Registry) string {
+	t.Helper()
+
+	server := httptest.NewServer(NewServer("", r).Handler)
+	defer server.Close()
+
+	res, err := http.Get(server.URL + PathMetrics)
+	require.NoError(t, err)
+	defer res.Body.Close()
+
+	require.Equal(t, http.StatusOK, res.StatusCode)
+	require.Equal(t, ContentType, res.Header.Get("Content-Type"))
+
+	body, err := io.ReadAll(res.Body)
+	require.NoError(t, err)
+
+	return string(body)
+}
+
+func TestInstrument(t *testing.T) {
+	kit := testkit.New(t)
+	r := NewRegistry()
+	rt := Instrument(kit.Manager, r)
+
+	probe := kit.Probe("probe")
+	probe.Respond(func(ctx context.Context, msg proto.Message, res proto.Message) error {
+		actx, _ := model.ActorContextFromContext(ctx)
+
+		switch msg.(*wrapperspb.StringValue).Value {
+		case "fail":
+			return errors.New("failed")
+		case "later":
+			_, err := actx.After(time.Hour, wrapperspb.String("fired"))
+			return err
+		}
+
+		return nil
+	})
+
+	kit.Send(probe.Address(), wrapperspb.String("later"))
+	err := kit.Manager.Send(context.Background(), probe.Address(), wrapperspb.String("fail"), time.Second)
+	require.Error(t, err)
+
+	kit.Register(actorpb.InventoryKind, actor.InventoryActorFactory)
+	inventory := model.Address{Kind: actorpb.InventoryKind, ID: uuid.New()}
+	for _, name := range []string{"farm", "mill"} {
+		kit.Ask(inventory, &message.BuildRequest{Name: name, Duration: "1m"}, &message.BuildResponse{})
+	}
+
+	stopped := model.Address{Kind: "probe", ID: uuid.New()}
+	kit.Send(stopped, wrapperspb.String("hello"))
+	require.NoError(t, kit.Manager.Stop(context.Background(), stopped))
+
+	// The first build leaves the queue once the inventory starts it, with a
+	// durable timer waking the inventory when it is due.
+	require.Eventually(t, func() bool {
+		rt.Collect(kit.Manager.Stats())
+		return rt.Queues.Value(actorpb.InventoryKind, actor.QueueBuild) == 1 && rt.Timers.Value() == 2
+	}, testkit.DefaultTimeout, time.Millisecond)
+
+	body := scrape(t, r)
+	for _, line := range []string{
+		`actor_active{kind="probe"} 1`,
+		`actor_activations_total{kind="probe"} 2`,
+		`actor_passivations_total{kind="probe"} 1`,
+		`actor_mailbox_depth{kind="probe"} 0`,
+		`actor_messages_sent_total{kind="probe",message="google.protobuf.StringValue"} 3`,
+		`actor_messages_received_total{kind="probe",message="google.protobuf.StringValue"} 3`,
+		`actor_messages_failed_total{kind="probe",message="google.protobuf.StringValue"} 1`,
+		`actor_messages_sent_total{kind="inventory",message="message.BuildRequest"} 2`,
+		`actor_handler_duration_seconds_count{kind="inventory",message="message.BuildRequest"} 2`,
+		`actor_queue_length{kind="inventory",queue="build"} 1`,
+		`actor_queue_length{kind="inventory",queue="craft"} 0`,
+		`actor_timers_pending 2`,
+		`actor_schedules_active 0`,
+	} {
+		require.Contains(t, body, line+"\n")
+	}
+
+	kit.Advance(time.Hour)
+	probe.Receive(testkit.DefaultTimeout)
+	probe.Receive(testkit.DefaultTimeout)
+	probe.Receive(testkit.DefaultTimeout)
+	probe.Receive(testkit.DefaultTimeout)
+
+	// The second build starts once the first one finishes, so its wake-up
+	// timer needs the clock to move on again.
+	require.Eventually(t, func() bool {
+		kit.Advance(time.Hour)
+		rt.Collect(kit.Manager.Stats())
+		return rt.Timers.Value() == 0
+	}, testkit.DefaultTimeout, time.Millisecond)
+}
@@ -4,7 +4,9 @@ import (
 	"context"
 	"crypto/sha256"
 	"fmt"
+	"time"
 
+	"github.com/gnarloqgames/ga-actor-poc/message"
 	"github.com/google/uuid"
 	"google.golang.org/protobuf/proto"
 )
@@ -12,7 +14,10 @@ import (
 type ContextKey string
 
 const (
-	KeyID ContextKey = "id"
+	KeyActorContext ContextKey = "actor_context"
+	KeyClock        ContextKey = "clock"
+	KeyExecutor     ContextKey = "executor"
+	KeyEnvelope     ContextKey = "envelope"
 )
 
 type Address struct {
@@ -36,3 +41,46 @@ type Actor interface {
 	Destroy(ctx context.Context)
 	Receive(ctx context.Context, msg proto.Message, res proto.Message) error
 }
+
+// QueueReporter is implemented by actors that queue work of their own, such as
+// builds, so that the manager can report the lengths of their queues by name.
+type QueueReporter interface {
+	QueueLengths() map[string]int
+}
+
+// Inspector is implemented by actors that can describe their state, for
+// debugging. The state is encoded as JSON.
+type Inspector interface {
+	Inspect() any
+}
+
+// Asker delivers msg to the actor at address and lets it fill res. The manager
+// implements it.
+type Asker interface {
+	Ask(ctx context.Context, address Address, msg proto.Message, res proto.Message, timeout time.Duration, opts ...SendOption) error
+}
+
+// KindRegistry registers the factory creating the actors of a kind. The
+// manager implements it.
+type KindRegistry interface {
+	NewKind(kind string, factory func(ctx context.Context) Actor) error
+}
+
+func (a Address) Message() *message.Address {
+	return &message.Address{
+		Kind: a.Kind,
+		ID:   a.ID.String(),
+	}
+}
+
+func AddressFromMessage(msg *message.Address) (Address, error) {
+	id, err := uuid.Parse(msg.GetID())
+	if err != nil {
+		return Address{}, fmt.Errorf("invalid address id: %w", err)
+	}
+
+	return Address{
+		Kind: msg.GetKind(),
+		ID:   id,
+	}, nil
+}
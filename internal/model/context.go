@@ -0,0 +1,97 @@
+package model
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"time"
+
+	"github.com/google/uuid"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// ErrNotActive is returned when stopping an actor that is not running.
+var ErrNotActive = errors.New("actor is not active")
+
+// ActorContext is the handle an actor has on the manager running it. The
+// factory of a kind finds it in the context it is called with, and every
+// Receive finds one bound to the message being handled.
+type ActorContext interface {
+	// Self returns the address of the actor.
+	Self() Address
+	// Sender returns the address of the actor that sent the message being
+	// received. It reports false outside of Receive and for messages sent
+	// from outside of an actor.
+	Sender() (Address, bool)
+	// Logger returns a logger carrying the kind and ID of the actor.
+	Logger() *slog.Logger
+
+	// Send delivers msg to the actor at address with this actor as the
+	// sender.
+	Send(ctx context.Context, address Address, msg proto.Message, timeout time.Duration, opts ...SendOption) error
+	// Ask delivers msg to the actor at address with this actor as the sender
+	// and lets it fill res.
+	Ask(ctx context.Context, address Address, msg proto.Message, res proto.Message, timeout time.Duration, opts ...SendOption) error
+	// Tell sends msg to the actor itself.
+	Tell(ctx context.Context, msg proto.Message, timeout time.Duration) error
+
+	// After delivers msg to the actor itself once d has elapsed, with the
+	// headers of the message being received. The timer is durable and can
+	// be cancelled with CancelTimer.
+	After(d time.Duration, msg proto.Message) (uuid.UUID, error)
+	// CancelTimer stops a timer started with After.
+	CancelTimer(id uuid.UUID) error
+
+	// SaveState replaces the state saved for the actor, which outlives the
+	// actor and is found again by LoadState once it is activated again.
+	SaveState(state proto.Message) error
+	// LoadState fills state with the state the actor last saved. It reports
+	// false if the actor never saved any.
+	LoadState(state proto.Message) (bool, error)
+
+	// Parent returns the address of the actor that spawned this one. It
+	// reports false for actors activated by a message.
+	Parent() (Address, bool)
+	// Children returns the addresses of the running children of the actor
+	// in the order they were spawned.
+	Children() []Address
+	// Spawn activates a new actor of a registered kind as a child.
+	Spawn(kind string) (Address, error)
+	// SpawnActor makes an actor created by the caller a child, addressable
+	// by its kind and ID.
+	SpawnActor(child Actor) (Address, error)
+	// Stop stops the actor itself or one of its children, after stopping
+	// their own children. The parent of the stopped actor receives a
+	// message.Terminated.
+	Stop(ctx context.Context, address Address) error
+
+	// Watch makes the actor receive a message.Terminated once the actor at
+	// target stops, or right away if it is not running.
+	Watch(target Address) error
+	// Unwatch cancels a watch that has not fired yet.
+	Unwatch(target Address)
+
+	// Publish hands event to the subscribers of topic and of its type,
+	// with this actor as the publisher.
+	Publish(ctx context.Context, topic string, event proto.Message)
+	// Subscribe makes the actor receive the events published on topic
+	// with the given type until it stops. An empty topic or type matches
+	// every event.
+	Subscribe(topic string, eventType protoreflect.FullName) uuid.UUID
+	// Unsubscribe ends a subscription made with Subscribe.
+	Unsubscribe(id uuid.UUID) bool
+}
+
+// WithActorContext returns a copy of ctx carrying actx.
+func WithActorContext(ctx context.Context, actx ActorContext) context.Context {
+	return context.WithValue(ctx, KeyActorContext, actx)
+}
+
+// ActorContextFromContext returns the actor context stored in ctx. It reports
+// false when ctx was not created by a manager for an actor.
+func ActorContextFromContext(ctx context.Context) (ActorContext, bool) {
+	actx, ok := ctx.Value(KeyActorContext).(ActorContext)
+
+	return actx, ok
+}
@@ -0,0 +1,52 @@
+package model
+
+import (
+	"context"
+
+	"github.com/gnarloqgames/ga-actor-poc/message"
+)
+
+// SendOption sets a field of the envelope the manager creates for a delivery.
+type SendOption func(envelope *message.Envelope)
+
+// WithSender overrides the sender found in the context of the caller.
+func WithSender(address Address) SendOption {
+	return func(envelope *message.Envelope) {
+		envelope.Sender = address.Message()
+	}
+}
+
+// WithReplyTo sends responses to address instead of the sender.
+func WithReplyTo(address Address) SendOption {
+	return func(envelope *message.Envelope) {
+		envelope.ReplyTo = address.Message()
+	}
+}
+
+// WithCorrelationID replaces the generated correlation ID, usually to answer
+// a request with the ID it arrived with.
+func WithCorrelationID(id string) SendOption {
+	return func(envelope *message.Envelope) {
+		envelope.CorrelationID = id
+	}
+}
+
+// WithHeader sets a header on the envelope.
+func WithHeader(key string, value string) SendOption {
+	return func(envelope *message.Envelope) {
+		envelope.Headers[key] = value
+	}
+}
+
+// WithEnvelope returns a copy of ctx carrying envelope.
+func WithEnvelope(ctx context.Context, envelope *message.Envelope) context.Context {
+	return context.WithValue(ctx, KeyEnvelope, envelope)
+}
+
+// EnvelopeFromContext returns the envelope of the message being received. It
+// reports false outside of Receive.
+func EnvelopeFromContext(ctx context.Context) (*message.Envelope, bool) {
+	envelope, ok := ctx.Value(KeyEnvelope).(*message.Envelope)
+
+	return envelope, ok
+}
@@ -0,0 +1,227 @@
+package modifier
+
+import (
+	"log/slog"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type Scope string
+
+type Target string
+
+type Operation string
+
+const (
+	AttributeModifierID string = "modifier_id"
+	AttributeScope      string = "scope"
+	AttributeTarget     string = "target"
+	AttributeOperation  string = "operation"
+	AttributeValue      string = "value"
+
+	ScopeGlobal    Scope = "global"
+	ScopePlayer    Scope = "player"
+	ScopeInventory Scope = "inventory"
+
+	TargetBuildDuration Target = "build_duration"
+	TargetBuildCost     Target = "build_cost"
+	TargetProduction    Target = "production"
+
+	OperationAdd      Operation = "add"
+	OperationMultiply Operation = "multiply"
+)
+
+// Default is the registry inventories consult unless they are given their own.
+var Default = NewRegistry()
+
+var scopeOrder = map[Scope]int{
+	ScopeGlobal:    0,
+	ScopePlayer:    1,
+	ScopeInventory: 2,
+}
+
+var operationOrder = map[Operation]int{
+	OperationAdd:      0,
+	OperationMultiply: 1,
+}
+
+// Modifier changes a value computed by an inventory while it is active.
+// Additive modifiers are applied before multiplicative ones.
+type Modifier struct {
+	ID   uuid.UUID
+	Name string
+
+	Scope   Scope
+	Subject uuid.UUID
+
+	Target    Target
+	Resource  string
+	Operation Operation
+	Value     float64
+
+	Start time.Time
+	End   time.Time
+}
+
+func (m Modifier) Attributes() []any {
+	return []any{
+		AttributeModifierID, m.ID.String(),
+		AttributeScope, m.Scope,
+		AttributeTarget, m.Target,
+		AttributeOperation, m.Operation,
+		AttributeValue, m.Value,
+	}
+}
+
+// Active reports whether at falls within the modifier's lifetime. A zero
+// Start or End leaves that side of the interval open.
+func (m Modifier) Active(at time.Time) bool {
+	if !m.Start.IsZero() && at.Before(m.Start) {
+		return false
+	}
+	if !m.End.IsZero() && !at.Before(m.End) {
+		return false
+	}
+
+	return true
+}
+
+// Subjects identifies who a value is being computed for.
+type Subjects struct {
+	Player    uuid.UUID
+	Inventory uuid.UUID
+}
+
+func (m Modifier) appliesTo(subjects Subjects) bool {
+	switch m.Scope {
+	case ScopeGlobal:
+		return true
+	case ScopePlayer:
+		return subjects.Player != uuid.Nil && m.Subject == subjects.Player
+	case ScopeInventory:
+		return subjects.Inventory != uuid.Nil && m.Subject == subjects.Inventory
+	default:
+		return false
+	}
+}
+
+// Effect records the change a single modifier made to a value.
+type Effect struct {
+	Modifier Modifier
+	Resource string
+	Before   float64
+	After    float64
+}
+
+type Registry struct {
+	mx *sync.Mutex
+
+	modifiers map[uuid.UUID]Modifier
+}
+
+func NewRegistry() *Registry {
+	return &Registry{
+		mx: &sync.Mutex{},
+
+		modifiers: make(map[uuid.UUID]Modifier),
+	}
+}
+
+// Add registers m, assigning it an ID if it has none, and returns the ID.
+func (r *Registry) Add(m Modifier) uuid.UUID {
+	r.mx.Lock()
+	defer r.mx.Unlock()
+
+	if m.ID == uuid.Nil {
+		m.ID = uuid.New()
+	}
+	r.modifiers[m.ID] = m
+
+	slog.Info("modifier added", m.Attributes()...)
+
+	return m.ID
+}
+
+func (r *Registry) Remove(id uuid.UUID) {
+	r.mx.Lock()
+	defer r.mx.Unlock()
+
+	delete(r.modifiers, id)
+}
+
+// List returns every registered modifier in application order.
+func (r *Registry) List() []Modifier {
+	r.mx.Lock()
+	defer r.mx.Unlock()
+
+	modifiers := make([]Modifier, 0, len(r.modifiers))
+	for _, m := range r.modifiers {
+		modifiers = append(modifiers, m)
+	}
+	sortModifiers(modifiers)
+
+	return modifiers
+}
+
+// Active returns the modifiers that apply to subjects at the given time, in
+// application order.
+func (r *Registry) Active(subjects Subjects, at time.Time) []Modifier {
+	modifiers := r.List()
+
+	return slices.DeleteFunc(modifiers, func(m Modifier) bool {
+		return !m.Active(at) || !m.appliesTo(subjects)
+	})
+}
+
+// Apply computes the modified value of base for target. Resource selects
+// resource-specific modifiers and is empty for targets without resources. The
+// returned effects explain each step of the computation.
+func (r *Registry) Apply(target Target, resource string, subjects Subjects, at time.Time, base float64) (float64, []Effect) {
+	value := base
+	effects := make([]Effect, 0)
+
+	for _, m := range r.Active(subjects, at) {
+		if m.Target != target || (m.Resource != "" && m.Resource != resource) {
+			continue
+		}
+
+		before := value
+		switch m.Operation {
+		case OperationAdd:
+			value += m.Value
+		case OperationMultiply:
+			value *= m.Value
+		default:
+			continue
+		}
+		value = max(value, 0)
+
+		effects = append(effects, Effect{
+			Modifier: m,
+			Resource: resource,
+			Before:   before,
+			After:    value,
+		})
+	}
+
+	return value, effects
+}
+
+func sortModifiers(modifiers []Modifier) {
+	slices.SortFunc(modifiers, func(a, b Modifier) int {
+		if d := operationOrder[a.Operation] - operationOrder[b.Operation]; d != 0 {
+			return d
+		}
+		if d := scopeOrder[a.Scope] - scopeOrder[b.Scope]; d != 0 {
+			return d
+		}
+		if d := a.Start.Compare(b.Start); d != 0 {
+			return d
+		}
+
+		return slices.Compare(a.ID[:], b.ID[:])
+	})
+}
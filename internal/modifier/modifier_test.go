@@ -0,0 +1,114 @@
+package modifier
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func TestApply(t *testing.T) {
+	now := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	player := uuid.New()
+	inventory := uuid.New()
+
+	tests := []struct {
+		label           string
+		modifiers       []Modifier
+		target          Target
+		resource        string
+		expectedValue   float64
+		expectedEffects int
+	}{
+		{
+			label:           "none",
+			modifiers:       []Modifier{},
+			target:          TargetBuildDuration,
+			expectedValue:   100,
+			expectedEffects: 0,
+		},
+		{
+			label: "global multiply",
+			modifiers: []Modifier{
+				{Scope: ScopeGlobal, Target: TargetBuildDuration, Operation: OperationMultiply, Value: 0.5},
+			},
+			target:          TargetBuildDuration,
+			expectedValue:   50,
+			expectedEffects: 1,
+		},
+		{
+			label: "add before multiply",
+			modifiers: []Modifier{
+				{Scope: ScopeGlobal, Target: TargetBuildDuration, Operation: OperationMultiply, Value: 0.5},
+				{Scope: ScopeInventory, Subject: inventory, Target: TargetBuildDuration, Operation: OperationAdd, Value: 20},
+			},
+			target:          TargetBuildDuration,
+			expectedValue:   60,
+			expectedEffects: 2,
+		},
+		{
+			label: "other subjects",
+			modifiers: []Modifier{
+				{Scope: ScopePlayer, Subject: uuid.New(), Target: TargetBuildDuration, Operation: OperationMultiply, Value: 0.5},
+				{Scope: ScopeInventory, Subject: uuid.New(), Target: TargetBuildDuration, Operation: OperationMultiply, Value: 0.5},
+				{Scope: ScopePlayer, Subject: player, Target: TargetBuildDuration, Operation: OperationMultiply, Value: 2},
+			},
+			target:          TargetBuildDuration,
+			expectedValue:   200,
+			expectedEffects: 1,
+		},
+		{
+			label: "inactive",
+			modifiers: []Modifier{
+				{Scope: ScopeGlobal, Target: TargetBuildDuration, Operation: OperationMultiply, Value: 0.5, End: now},
+				{Scope: ScopeGlobal, Target: TargetBuildDuration, Operation: OperationMultiply, Value: 0.5, Start: now.Add(time.Second)},
+			},
+			target:          TargetBuildDuration,
+			expectedValue:   100,
+			expectedEffects: 0,
+		},
+		{
+			label: "resource",
+			modifiers: []Modifier{
+				{Scope: ScopeGlobal, Target: TargetBuildCost, Resource: "wood", Operation: OperationMultiply, Value: 0.1},
+				{Scope: ScopeGlobal, Target: TargetBuildCost, Resource: "stone", Operation: OperationMultiply, Value: 0.2},
+				{Scope: ScopeGlobal, Target: TargetBuildCost, Operation: OperationAdd, Value: -200},
+			},
+			target:          TargetBuildCost,
+			resource:        "wood",
+			expectedValue:   0,
+			expectedEffects: 2,
+		},
+	}
+
+	for _, tt := range tests {
+		tf := func(t *testing.T) {
+			registry := NewRegistry()
+			for _, m := range tt.modifiers {
+				registry.Add(m)
+			}
+
+			subjects := Subjects{Player: player, Inventory: inventory}
+			value, effects := registry.Apply(tt.target, tt.resource, subjects, now, 100)
+
+			require.InDelta(t, tt.expectedValue, value, 0.0001)
+			require.Len(t, effects, tt.expectedEffects)
+			if len(effects) > 0 {
+				require.Equal(t, float64(100), effects[0].Before)
+				require.Equal(t, value, effects[len(effects)-1].After)
+			}
+		}
+
+		t.Run(tt.label, tf)
+	}
+}
+
+func TestRemove(t *testing.T) {
+	registry := NewRegistry()
+	id := registry.Add(Modifier{Scope: ScopeGlobal, Target: TargetProduction, Operation: OperationMultiply, Value: 2})
+	require.Len(t, registry.List(), 1)
+
+	registry.Remove(id)
+	require.Empty(t, registry.List())
+}
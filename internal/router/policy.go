@@ -0,0 +1,134 @@
+package router
+
+import (
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"math/rand/v2"
+	"sync"
+	"sync/atomic"
+
+	"github.com/gnarloqgames/ga-actor-poc/internal/model"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+var ErrNoHashKey = errors.New("message has no hash key")
+
+// Routee is a worker of a router.
+type Routee struct {
+	Address model.Address
+
+	pending atomic.Int64
+}
+
+// Pending returns the number of messages the worker is handling.
+func (r *Routee) Pending() int {
+	return int(r.pending.Load())
+}
+
+// Policy chooses the workers a message is routed to. Policies are shared by
+// every router of a kind and must be safe for concurrent use.
+type Policy interface {
+	// Select returns the workers msg goes to. workers is never empty.
+	Select(msg proto.Message, workers []*Routee) ([]*Routee, error)
+}
+
+type roundRobin struct {
+	next *atomic.Uint64
+}
+
+// RoundRobin routes each message to the worker after the previous one.
+func RoundRobin() Policy {
+	return roundRobin{next: &atomic.Uint64{}}
+}
+
+func (p roundRobin) Select(msg proto.Message, workers []*Routee) ([]*Routee, error) {
+	i := (p.next.Add(1) - 1) % uint64(len(workers))
+
+	return workers[i : i+1], nil
+}
+
+type random struct {
+	mx     *sync.Mutex
+	random *rand.Rand
+}
+
+// Random routes each message to a worker drawn from r.
+func Random(r *rand.Rand) Policy {
+	return random{mx: &sync.Mutex{}, random: r}
+}
+
+func (p random) Select(msg proto.Message, workers []*Routee) ([]*Routee, error) {
+	p.mx.Lock()
+	i := p.random.IntN(len(workers))
+	p.mx.Unlock()
+
+	return workers[i : i+1], nil
+}
+
+type broadcast struct{}
+
+// Broadcast routes every message to all workers.
+func Broadcast() Policy {
+	return broadcast{}
+}
+
+func (broadcast) Select(msg proto.Message, workers []*Routee) ([]*Routee, error) {
+	return workers, nil
+}
+
+type consistentHash struct {
+	field protoreflect.Name
+}
+
+// ConsistentHash routes messages with the same value in field to the same
+// worker. Resizing the pool only moves the keys of the workers added or
+// removed.
+func ConsistentHash(field protoreflect.Name) Policy {
+	return consistentHash{field: field}
+}
+
+func (p consistentHash) Select(msg proto.Message, workers []*Routee) ([]*Routee, error) {
+	m := msg.ProtoReflect()
+	fd := m.Descriptor().Fields().ByName(p.field)
+	if fd == nil {
+		return nil, fmt.Errorf("%w: %s has no field %s", ErrNoHashKey, m.Descriptor().FullName(), p.field)
+	}
+	key := fmt.Sprint(m.Get(fd).Interface())
+
+	// Rendezvous hashing: the worker scoring highest for the key wins.
+	var best *Routee
+	var bestScore uint64
+	for _, worker := range workers {
+		h := fnv.New64a()
+		h.Write(worker.Address.ID[:])
+		h.Write([]byte(key))
+
+		if score := h.Sum64(); best == nil || score > bestScore {
+			best, bestScore = worker, score
+		}
+	}
+
+	return []*Routee{best}, nil
+}
+
+type smallestMailbox struct{}
+
+// SmallestMailbox routes each message to the worker handling the fewest
+// messages, the oldest worker on ties. Messages are delivered synchronously,
+// so the mailbox of a worker is the messages it is still handling.
+func SmallestMailbox() Policy {
+	return smallestMailbox{}
+}
+
+func (smallestMailbox) Select(msg proto.Message, workers []*Routee) ([]*Routee, error) {
+	best := workers[0]
+	for _, worker := range workers[1:] {
+		if worker.Pending() < best.Pending() {
+			best = worker
+		}
+	}
+
+	return []*Routee{best}, nil
+}
@@ -0,0 +1,295 @@
+package router
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/gnarloqgames/ga-actor-poc/internal/clock"
+	"github.com/gnarloqgames/ga-actor-poc/internal/model"
+	"github.com/gnarloqgames/ga-actor-poc/message"
+	"github.com/google/uuid"
+	"google.golang.org/protobuf/proto"
+)
+
+const (
+	AttributeRouterKind string = "router_kind"
+	AttributeRouterID   string = "router_id"
+	AttributeWorkerKind string = "worker_kind"
+	AttributeSize       string = "size"
+
+	// DefaultTimeout bounds forwarded messages that arrive without a
+	// deadline.
+	DefaultTimeout = 10 * time.Second
+
+	// DefaultMaxSize is the largest pool a router accepts unless its options
+	// say otherwise.
+	DefaultMaxSize = 1024
+)
+
+var ErrInvalidSize = errors.New("invalid pool size")
+
+type PoolOptions struct {
+	// MaxSize bounds the size of the pool, including sizes asked for with
+	// message.Resize. Zero means DefaultMaxSize.
+	MaxSize int
+}
+
+var _ model.Actor = (*Router)(nil)
+
+// Router fronts a pool of workers of one kind, spawned as its children, and
+// routes every message it receives to them according to its policy. Workers
+// that stop on their own are replaced. A message.Resize changes the size of
+// the pool instead of being routed.
+type Router struct {
+	ID         uuid.UUID
+	WorkerKind string
+
+	actx   model.ActorContext
+	clock  clock.Clock
+	policy Policy
+
+	mx      *sync.Mutex
+	size    int
+	maxSize int
+	workers []*Routee
+	stopped bool
+}
+
+// Pool returns the factory of a router kind whose routers start size workers
+// of workerKind when they receive their first message. Register it with
+// Manager.NewKind. It returns ErrInvalidSize if size is negative or above the
+// maximum size of options.
+func Pool(workerKind string, size int, policy Policy, options PoolOptions) (func(ctx context.Context) model.Actor, error) {
+	maxSize := options.MaxSize
+	if maxSize == 0 {
+		maxSize = DefaultMaxSize
+	}
+	if maxSize < 0 {
+		return nil, fmt.Errorf("%w: maximum size %d is negative", ErrInvalidSize, maxSize)
+	}
+	if err := checkSize(size, maxSize); err != nil {
+		return nil, err
+	}
+
+	return func(ctx context.Context) model.Actor {
+		actx, ok := model.ActorContextFromContext(ctx)
+		if !ok {
+			panic("router created without an actor context")
+		}
+
+		return &Router{
+			ID:         actx.Self().ID,
+			WorkerKind: workerKind,
+
+			actx:   actx,
+			clock:  clock.FromContext(ctx),
+			policy: policy,
+
+			mx:      &sync.Mutex{},
+			size:    size,
+			maxSize: maxSize,
+			workers: make([]*Routee, 0, size),
+		}
+	}, nil
+}
+
+func checkSize(size int, maxSize int) error {
+	if size < 0 || size > maxSize {
+		return fmt.Errorf("%w: %d is not between 0 and %d", ErrInvalidSize, size, maxSize)
+	}
+
+	return nil
+}
+
+func (r *Router) Attributes() []any {
+	return []any{
+		AttributeRouterKind, r.GetKind(),
+		AttributeRouterID, r.ID.String(),
+		AttributeWorkerKind, r.WorkerKind,
+	}
+}
+
+func (r *Router) GetID() uuid.UUID {
+	return r.ID
+}
+
+func (r *Router) GetKind() string {
+	return r.actx.Self().Kind
+}
+
+func (r *Router) Start(ctx context.Context) {}
+
+// Destroy stops routing. The manager stops the workers along with the router.
+func (r *Router) Destroy(ctx context.Context) {
+	r.mx.Lock()
+	defer r.mx.Unlock()
+
+	r.stopped = true
+	r.workers = nil
+}
+
+// Workers returns the addresses of the workers in the order they were
+// spawned.
+func (r *Router) Workers() []model.Address {
+	r.mx.Lock()
+	defer r.mx.Unlock()
+
+	addresses := make([]model.Address, 0, len(r.workers))
+	for _, worker := range r.workers {
+		addresses = append(addresses, worker.Address)
+	}
+
+	return addresses
+}
+
+// Resize spawns or stops workers until the pool has size of them. The most
+// recently spawned workers are stopped first. It returns ErrInvalidSize, and
+// keeps the pool as it is, if size is negative or above the maximum size.
+func (r *Router) Resize(ctx context.Context, size int) error {
+	r.mx.Lock()
+	defer r.mx.Unlock()
+
+	if err := checkSize(size, r.maxSize); err != nil {
+		return err
+	}
+
+	r.size = size
+
+	return r.fill(ctx)
+}
+
+// fill spawns or stops workers to match r.size. The caller must hold r.mx.
+func (r *Router) fill(ctx context.Context) error {
+	if r.stopped {
+		return fmt.Errorf("%w: router %s", model.ErrNotActive, r.ID)
+	}
+
+	var errs []error
+
+	for len(r.workers) < r.size {
+		address, err := r.actx.Spawn(r.WorkerKind)
+		if err != nil {
+			errs = append(errs, err)
+			break
+		}
+
+		r.workers = append(r.workers, &Routee{Address: address})
+	}
+
+	for len(r.workers) > r.size {
+		worker := r.workers[len(r.workers)-1]
+		r.workers = r.workers[:len(r.workers)-1]
+
+		if err := r.actx.Stop(ctx, worker.Address); err != nil && !errors.Is(err, model.ErrNotActive) {
+			errs = append(errs, err)
+		}
+	}
+
+	slog.Info("router pool resized", append(r.Attributes(), AttributeSize, len(r.workers))...)
+
+	return errors.Join(errs...)
+}
+
+// Receive resizes the pool on a message.Resize, replaces workers on a
+// message.Terminated and routes every other message.
+func (r *Router) Receive(ctx context.Context, msg proto.Message, res proto.Message) error {
+	switch msg := msg.(type) {
+	case *message.Resize:
+		return r.Resize(ctx, int(msg.Size))
+	case *message.Terminated:
+		return r.replace(ctx, msg)
+	}
+
+	workers, err := r.route(ctx, msg)
+	if err != nil {
+		return err
+	}
+
+	errs := make([]error, 0, len(workers))
+	for i, worker := range workers {
+		// Only the first worker answers a broadcast.
+		if i > 0 {
+			res = nil
+		}
+
+		errs = append(errs, r.forward(ctx, worker, msg, res))
+	}
+
+	return errors.Join(errs...)
+}
+
+func (r *Router) route(ctx context.Context, msg proto.Message) ([]*Routee, error) {
+	r.mx.Lock()
+	defer r.mx.Unlock()
+
+	if len(r.workers) < r.size {
+		if err := r.fill(ctx); err != nil {
+			return nil, err
+		}
+	}
+	if len(r.workers) == 0 {
+		return nil, fmt.Errorf("router %s has no workers", r.ID)
+	}
+
+	return r.policy.Select(msg, slices.Clone(r.workers))
+}
+
+// forward hands msg to worker on behalf of the original sender, keeping the
+// correlation ID and deadline of the message.
+func (r *Router) forward(ctx context.Context, worker *Routee, msg proto.Message, res proto.Message) error {
+	timeout := DefaultTimeout
+	opts := make([]model.SendOption, 0, 3)
+
+	if envelope, ok := model.EnvelopeFromContext(ctx); ok {
+		if envelope.Deadline != nil {
+			timeout = envelope.Deadline.AsTime().Sub(r.clock.Now())
+		}
+
+		opts = append(opts, model.WithCorrelationID(envelope.CorrelationID))
+		if sender, err := model.AddressFromMessage(envelope.GetSender()); err == nil {
+			opts = append(opts, model.WithSender(sender))
+		}
+		if replyTo, err := model.AddressFromMessage(envelope.GetReplyTo()); err == nil {
+			opts = append(opts, model.WithReplyTo(replyTo))
+		}
+	}
+
+	worker.pending.Add(1)
+	defer worker.pending.Add(-1)
+
+	if res != nil {
+		return r.actx.Ask(ctx, worker.Address, msg, res, timeout, opts...)
+	}
+
+	return r.actx.Send(ctx, worker.Address, msg, timeout, opts...)
+}
+
+// replace spawns a new worker in place of one that stopped on its own.
+// Workers stopped by Resize are already out of the pool.
+func (r *Router) replace(ctx context.Context, terminated *message.Terminated) error {
+	address, err := model.AddressFromMessage(terminated.GetActor())
+	if err != nil {
+		return err
+	}
+
+	r.mx.Lock()
+	defer r.mx.Unlock()
+
+	i := slices.IndexFunc(r.workers, func(w *Routee) bool { return w.Address == address })
+	if i < 0 || r.stopped {
+		return nil
+	}
+
+	slog.Warn("router worker terminated",
+		append(r.Attributes(), "worker_id", address.ID.String(), "reason", terminated.Reason)...,
+	)
+
+	r.workers = slices.Delete(r.workers, i, i+1)
+
+	return r.fill(ctx)
+}
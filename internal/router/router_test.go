@@ -0,0 +1,237 @@
+package router
+
+import (
+	"context"
+	"math/rand/v2"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/gnarloqgames/ga-actor-poc/internal/model"
+	"github.com/gnarloqgames/ga-actor-poc/internal/testkit"
+	"github.com/gnarloqgames/ga-actor-poc/message"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+func newRouter(t *testing.T, size int, policy Policy) (*testkit.Kit, *testkit.Probe, *Router) {
+	t.Helper()
+
+	kit := testkit.New(t)
+	probe := kit.Probe("worker")
+	factory, err := Pool("worker", size, policy, PoolOptions{MaxSize: 4})
+	require.NoError(t, err)
+	kit.Register("router", factory)
+
+	router := kit.Actor(model.Address{Kind: "router", ID: uuid.New()}).(*Router)
+
+	return kit, probe, router
+}
+
+func self(r *Router) model.Address {
+	return model.Address{Kind: r.GetKind(), ID: r.ID}
+}
+
+// receivers sends msgs to r and returns the index of the worker each one
+// reached, in order.
+func receivers(t *testing.T, kit *testkit.Kit, probe *testkit.Probe, r *Router, msgs []proto.Message) []int {
+	t.Helper()
+
+	for _, msg := range msgs {
+		kit.Send(self(r), msg)
+	}
+
+	workers := r.Workers()
+	indexes := make([]int, 0, len(msgs))
+	for probe.Len() > 0 {
+		received := probe.Receive(testkit.DefaultTimeout)
+		indexes = append(indexes, slices.Index(workers, received.Address))
+	}
+
+	return indexes
+}
+
+func TestRouter(t *testing.T) {
+	texts := func(values ...string) []proto.Message {
+		msgs := make([]proto.Message, 0, len(values))
+		for _, value := range values {
+			msgs = append(msgs, wrapperspb.String(value))
+		}
+		return msgs
+	}
+
+	tests := []struct {
+		label    string
+		policy   Policy
+		msgs     []proto.Message
+		expected []int
+	}{
+		{
+			label:    "round robin",
+			policy:   RoundRobin(),
+			msgs:     texts("a", "b", "c", "d"),
+			expected: []int{0, 1, 2, 0},
+		},
+		{
+			label:    "broadcast",
+			policy:   Broadcast(),
+			msgs:     texts("a"),
+			expected: []int{0, 1, 2},
+		},
+		{
+			label:    "smallest mailbox",
+			policy:   SmallestMailbox(),
+			msgs:     texts("a", "b"),
+			expected: []int{0, 0},
+		},
+	}
+
+	for _, tt := range tests {
+		tf := func(t *testing.T) {
+			kit, probe, router := newRouter(t, 3, tt.policy)
+
+			require.Equal(t, tt.expected, receivers(t, kit, probe, router, tt.msgs))
+		}
+
+		t.Run(tt.label, tf)
+	}
+}
+
+func TestRandom(t *testing.T) {
+	kit, probe, router := newRouter(t, 3, Random(rand.New(rand.NewPCG(1, 2))))
+
+	indexes := receivers(t, kit, probe, router, []proto.Message{
+		wrapperspb.String("a"), wrapperspb.String("b"), wrapperspb.String("c"), wrapperspb.String("d"),
+	})
+	require.Len(t, indexes, 4)
+	for _, i := range indexes {
+		require.GreaterOrEqual(t, i, 0)
+	}
+}
+
+func TestConsistentHash(t *testing.T) {
+	kit, probe, router := newRouter(t, 3, ConsistentHash("TraceID"))
+
+	build := func(trace string) proto.Message { return &message.BuildRequest{TraceID: trace} }
+	indexes := receivers(t, kit, probe, router, []proto.Message{build("x"), build("y"), build("x"), build("y")})
+	require.Equal(t, indexes[0], indexes[2])
+	require.Equal(t, indexes[1], indexes[3])
+
+	// Removing a worker that does not own x keeps x where it was.
+	owner := router.Workers()[indexes[0]]
+	require.NoError(t, router.Resize(context.Background(), 2))
+	if slices.Contains(router.Workers(), owner) {
+		after := receivers(t, kit, probe, router, []proto.Message{build("x")})
+		require.Equal(t, owner, router.Workers()[after[0]])
+	}
+
+	err := kit.Manager.Send(context.Background(), self(router), wrapperspb.String("no key"), testkit.DefaultTimeout)
+	require.ErrorIs(t, err, ErrNoHashKey)
+}
+
+func TestSmallestMailbox(t *testing.T) {
+	workers := []*Routee{{}, {}, {}}
+	workers[0].pending.Store(2)
+	workers[1].pending.Store(1)
+	workers[2].pending.Store(1)
+
+	selected, err := SmallestMailbox().Select(nil, workers)
+	require.NoError(t, err)
+	require.Equal(t, []*Routee{workers[1]}, selected)
+}
+
+func TestRouterAsk(t *testing.T) {
+	kit, probe, router := newRouter(t, 2, Broadcast())
+	probe.Respond(func(ctx context.Context, msg proto.Message, res proto.Message) error {
+		if res != nil {
+			res.(*wrapperspb.StringValue).Value = "pong"
+		}
+		return nil
+	})
+
+	res := &wrapperspb.StringValue{}
+	kit.Ask(self(router), wrapperspb.String("ping"), res, model.WithCorrelationID("request"))
+	require.Equal(t, "pong", res.Value)
+
+	for range 2 {
+		received := probe.Receive(testkit.DefaultTimeout)
+		require.Equal(t, "request", received.Envelope.CorrelationID)
+	}
+}
+
+func TestResize(t *testing.T) {
+	kit, probe, router := newRouter(t, 3, RoundRobin())
+	kit.Send(self(router), wrapperspb.String("start"))
+	probe.Receive(testkit.DefaultTimeout)
+
+	workers := router.Workers()
+	require.Len(t, workers, 3)
+
+	kit.Send(self(router), &message.Resize{Size: 1})
+	require.Equal(t, workers[:1], router.Workers())
+	require.Equal(t, workers[:1], kit.Manager.Children(self(router)))
+
+	kit.Send(self(router), &message.Resize{Size: 2})
+	require.Len(t, router.Workers(), 2)
+	require.Equal(t, workers[0], router.Workers()[0])
+}
+
+func TestPoolInvalidSize(t *testing.T) {
+	tests := []struct {
+		label   string
+		size    int
+		options PoolOptions
+	}{
+		{label: "negative", size: -1},
+		{label: "above default maximum", size: DefaultMaxSize + 1},
+		{label: "above maximum", size: 5, options: PoolOptions{MaxSize: 4}},
+		{label: "negative maximum", size: 1, options: PoolOptions{MaxSize: -1}},
+	}
+
+	for _, tt := range tests {
+		tf := func(t *testing.T) {
+			_, err := Pool("worker", tt.size, RoundRobin(), tt.options)
+			require.ErrorIs(t, err, ErrInvalidSize)
+		}
+
+		t.Run(tt.label, tf)
+	}
+}
+
+func TestResizeInvalid(t *testing.T) {
+	kit, probe, router := newRouter(t, 2, RoundRobin())
+	kit.Send(self(router), wrapperspb.String("start"))
+	probe.Receive(testkit.DefaultTimeout)
+
+	workers := router.Workers()
+	require.Len(t, workers, 2)
+
+	require.ErrorIs(t, router.Resize(context.Background(), -1), ErrInvalidSize)
+	require.ErrorIs(t, router.Resize(context.Background(), 5), ErrInvalidSize)
+
+	err := kit.Manager.Send(context.Background(), self(router), &message.Resize{Size: 5}, testkit.DefaultTimeout)
+	require.ErrorIs(t, err, ErrInvalidSize)
+	err = kit.Manager.Send(context.Background(), self(router), &message.Resize{Size: 1 << 31}, testkit.DefaultTimeout)
+	require.ErrorIs(t, err, ErrInvalidSize)
+
+	require.Equal(t, workers, router.Workers())
+
+	kit.Send(self(router), &message.Resize{Size: 4})
+	require.Len(t, router.Workers(), 4)
+}
+
+func TestReplaceTerminatedWorker(t *testing.T) {
+	kit, probe, router := newRouter(t, 2, RoundRobin())
+	kit.Send(self(router), wrapperspb.String("start"))
+	probe.Receive(testkit.DefaultTimeout)
+
+	stopped := router.Workers()[0]
+	require.NoError(t, kit.Manager.Stop(context.Background(), stopped))
+
+	require.Eventually(t, func() bool {
+		workers := router.Workers()
+		return len(workers) == 2 && !slices.Contains(workers, stopped)
+	}, testkit.DefaultTimeout, time.Millisecond)
+}
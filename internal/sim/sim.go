@@ -0,0 +1,197 @@
+package sim
+
+import (
+	"context"
+	"math/rand/v2"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/gnarloqgames/ga-actor-poc/internal/clock"
+	"github.com/gnarloqgames/ga-actor-poc/internal/manager"
+	"github.com/gnarloqgames/ga-actor-poc/internal/model"
+	"github.com/google/uuid"
+	"google.golang.org/protobuf/proto"
+)
+
+// DeliveryTimeout is passed to the manager for every simulated delivery. The
+// simulation never waits on real time, so it only matters to actors that
+// inspect their context deadline.
+const DeliveryTimeout = time.Minute
+
+// Epoch is the time every simulation starts at.
+var Epoch = time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+var _ clock.Clock = (*Simulation)(nil)
+
+// Simulation runs a manager on a single goroutine. Messages sent through the
+// simulation, work actors hand to their executor and timer callbacks all become
+// tasks, and every step runs one task picked by a source seeded with the
+// simulation seed. The IDs the manager gives spawned actors, timers, schedules,
+// subscriptions and envelopes are drawn from the seed too. Running the same
+// actors with the same seed and the same calls replays the exact same
+// interleaving.
+//
+// Actors must run all of their background work through the executor and the
+// clock found in their context. Anything started on a goroutine of its own
+// escapes the simulation, as do IDs actors generate themselves.
+type Simulation struct {
+	Manager *manager.Manager
+
+	seed  uint64
+	clock *clock.Fake
+
+	mx    *sync.Mutex
+	rand  *rand.Rand
+	tasks []func()
+	steps int
+}
+
+func New(seed uint64) *Simulation {
+	s := &Simulation{
+		seed:  seed,
+		clock: clock.NewFake(Epoch),
+
+		mx:    &sync.Mutex{},
+		rand:  rand.New(rand.NewPCG(seed, seed)),
+		tasks: make([]func(), 0),
+	}
+
+	s.Manager = manager.NewManagerWithClock(s)
+	s.Manager.UseExecutor(s)
+	s.Manager.UseRandom(rand.New(rand.NewPCG(seed, ^seed)))
+	s.Manager.UseIDs(rand.New(rand.NewPCG(^seed, seed)))
+
+	return s
+}
+
+// Seed returns the seed the simulation was created with.
+func (s *Simulation) Seed() uint64 {
+	return s.seed
+}
+
+// Rand returns the seeded source of the simulation. Tests generating random
+// workloads should draw from it so the seed covers them as well. Like the rest
+// of the simulation it must only be used from the goroutine driving it.
+func (s *Simulation) Rand() *rand.Rand {
+	return s.rand
+}
+
+// Steps returns the number of tasks run so far.
+func (s *Simulation) Steps() int {
+	s.mx.Lock()
+	defer s.mx.Unlock()
+
+	return s.steps
+}
+
+// Pending returns the number of tasks waiting to run.
+func (s *Simulation) Pending() int {
+	s.mx.Lock()
+	defer s.mx.Unlock()
+
+	return len(s.tasks)
+}
+
+// Go queues fn as a task.
+func (s *Simulation) Go(fn func()) {
+	s.mx.Lock()
+	defer s.mx.Unlock()
+
+	s.tasks = append(s.tasks, fn)
+}
+
+func (s *Simulation) Now() time.Time {
+	return s.clock.Now()
+}
+
+// AfterFunc queues fn as a task once the simulated time reaches d from now.
+func (s *Simulation) AfterFunc(d time.Duration, fn func()) clock.Timer {
+	return s.clock.AfterFunc(d, func() { s.Go(fn) })
+}
+
+// Send queues the delivery of msg to address as a task. done, if set, is called
+// with the result of the delivery from the same task.
+func (s *Simulation) Send(address model.Address, msg proto.Message, done func(err error)) {
+	s.Go(func() {
+		err := s.Manager.Send(context.Background(), address, msg, DeliveryTimeout)
+		if done != nil {
+			done(err)
+		}
+	})
+}
+
+// Step runs one pending task. When none is pending it moves the clock to the
+// next timer instead, which queues the callbacks due at that time. It reports
+// false if there was neither a task nor a timer.
+func (s *Simulation) Step() bool {
+	s.mx.Lock()
+	if len(s.tasks) == 0 {
+		s.mx.Unlock()
+
+		at, ok := s.clock.Next()
+		if !ok {
+			return false
+		}
+		s.clock.Set(at)
+
+		return true
+	}
+
+	i := s.rand.IntN(len(s.tasks))
+	task := s.tasks[i]
+	s.tasks = slices.Delete(s.tasks, i, i+1)
+	s.steps++
+	s.mx.Unlock()
+
+	task()
+
+	return true
+}
+
+// Run steps until no task or timer is left. Recurring schedules never run out,
+// use RunFor with them.
+func (s *Simulation) Run() {
+	for s.Step() {
+	}
+}
+
+// RunUntilIdle runs tasks until none is pending without moving the clock.
+func (s *Simulation) RunUntilIdle() {
+	for s.Pending() > 0 {
+		s.Step()
+	}
+}
+
+// RunFor runs tasks and fires timers until d of simulated time has passed and
+// nothing due by then is left.
+func (s *Simulation) RunFor(d time.Duration) {
+	until := s.Now().Add(d)
+
+	for {
+		s.RunUntilIdle()
+
+		at, ok := s.clock.Next()
+		if !ok || at.After(until) {
+			s.clock.Set(until)
+			return
+		}
+		s.clock.Set(at)
+	}
+}
+
+// NewAddress returns an address of kind whose ID is drawn from the seeded
+// source, so workloads generated from it replay with the seed.
+func (s *Simulation) NewAddress(kind string) model.Address {
+	s.mx.Lock()
+	defer s.mx.Unlock()
+
+	var id uuid.UUID
+	for i := range id {
+		id[i] = byte(s.rand.UintN(256))
+	}
+	id[6] = id[6]&0x0f | 0x40
+	id[8] = id[8]&0x3f | 0x80
+
+	return model.Address{Kind: kind, ID: id}
+}
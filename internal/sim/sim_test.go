@@ -0,0 +1,121 @@
+package sim
+
+import (
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/gnarloqgames/ga-actor-poc/internal/manager"
+	"github.com/gnarloqgames/ga-actor-poc/internal/testkit"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+// deliveryOrder sends ten numbered messages to a probe and returns the order in
+// which the simulation delivered them.
+func deliveryOrder(t *testing.T, seed uint64) []string {
+	s := New(seed)
+	probe := testkit.NewProbe(t, "probe")
+	require.NoError(t, s.Manager.NewKind("probe", probe.Factory))
+
+	for i := range 10 {
+		s.Send(s.NewAddress("probe"), wrapperspb.String(string(rune('a'+i))), nil)
+	}
+	s.Run()
+
+	order := make([]string, 0, 10)
+	for probe.Len() > 0 {
+		msg := testkit.ExpectMessageOf[*wrapperspb.StringValue](probe, 0)
+		order = append(order, msg.Value)
+	}
+
+	return order
+}
+
+func TestReplay(t *testing.T) {
+	first := deliveryOrder(t, 42)
+	require.Len(t, first, 10)
+	require.Equal(t, first, deliveryOrder(t, 42))
+
+	differs := false
+	for seed := uint64(0); seed < 10 && !differs; seed++ {
+		differs = !slices.Equal(first, deliveryOrder(t, seed))
+	}
+	require.True(t, differs, "every seed delivered in the same order")
+}
+
+func TestRunFor(t *testing.T) {
+	s := New(1)
+	probe := testkit.NewProbe(t, "probe")
+	require.NoError(t, s.Manager.NewKind("probe", probe.Factory))
+
+	_, err := s.Manager.Schedule(probe.Address(), manager.Every(time.Hour), wrapperspb.String("tick"), manager.ScheduleOptions{
+		Jitter: time.Minute,
+	})
+	require.NoError(t, err)
+
+	s.RunFor(24*time.Hour + time.Minute)
+
+	require.Equal(t, 24, probe.Len())
+	require.Equal(t, Epoch.Add(24*time.Hour+time.Minute), s.Now())
+}
+
+func TestSendDone(t *testing.T) {
+	s := New(1)
+	probe := testkit.NewProbe(t, "probe")
+	require.NoError(t, s.Manager.NewKind("probe", probe.Factory))
+
+	var sent proto.Message
+	s.Send(probe.Address(), wrapperspb.String("ping"), func(err error) {
+		require.NoError(t, err)
+		sent = wrapperspb.String("done")
+	})
+	s.Send(s.NewAddress("missing"), wrapperspb.String("lost"), func(err error) {
+		require.Error(t, err)
+	})
+
+	require.Equal(t, 2, s.Pending())
+	s.RunUntilIdle()
+
+	require.Equal(t, 2, s.Steps())
+	require.NotNil(t, sent)
+	probe.ExpectMessage(wrapperspb.String("ping"), 0)
+}
+
+// managerIDs returns the IDs the manager of a simulation seeded with seed gives
+// a spawned actor, a timer, a schedule and a subscription.
+func managerIDs(t *testing.T, seed uint64) []uuid.UUID {
+	s := New(seed)
+	probe := testkit.NewProbe(t, "probe")
+	require.NoError(t, s.Manager.NewKind("probe", probe.Factory))
+
+	child, err := s.Manager.Spawn(probe.Address(), "probe")
+	require.NoError(t, err)
+
+	timer, err := s.Manager.ScheduleAt(probe.Address(), s.Now().Add(time.Hour), wrapperspb.String("timer"))
+	require.NoError(t, err)
+
+	schedule, err := s.Manager.Schedule(probe.Address(), manager.Every(time.Hour), wrapperspb.String("tick"), manager.ScheduleOptions{})
+	require.NoError(t, err)
+
+	subscription := s.Manager.Subscribe(manager.Filter{}, probe.Address())
+
+	s.Send(probe.Address(), wrapperspb.String("hello"), nil)
+	s.RunUntilIdle()
+	correlation, err := uuid.Parse(probe.Receive(testkit.DefaultTimeout).Envelope.CorrelationID)
+	require.NoError(t, err)
+
+	return []uuid.UUID{child.ID, timer, schedule, subscription, correlation}
+}
+
+func TestReplayIDs(t *testing.T) {
+	first := managerIDs(t, 42)
+	require.Equal(t, first, managerIDs(t, 42))
+	require.NotEqual(t, first, managerIDs(t, 43))
+
+	for _, id := range first {
+		require.Equal(t, uuid.Version(4), id.Version())
+	}
+}
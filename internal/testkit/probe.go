@@ -0,0 +1,188 @@
+package testkit
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gnarloqgames/ga-actor-poc/internal/model"
+	"github.com/gnarloqgames/ga-actor-poc/message"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+)
+
+// Received is a message recorded by a probe.
+type Received struct {
+	Address  model.Address
+	Envelope *message.Envelope
+	Message  proto.Message
+	Response proto.Message
+}
+
+// Probe records the messages received by the actors it creates and lets a test
+// assert on them in order.
+type Probe struct {
+	tb   testing.TB
+	kind string
+	id   uuid.UUID
+
+	mx       *sync.Mutex
+	received []Received
+	changed  chan struct{}
+	respond  func(ctx context.Context, msg proto.Message, res proto.Message) error
+}
+
+func NewProbe(tb testing.TB, kind string) *Probe {
+	return &Probe{
+		tb:   tb,
+		kind: kind,
+		id:   uuid.New(),
+
+		mx:       &sync.Mutex{},
+		received: make([]Received, 0),
+		changed:  make(chan struct{}, 1),
+	}
+}
+
+// Factory creates a probe actor with the ID of the actor context in ctx.
+func (p *Probe) Factory(ctx context.Context) model.Actor {
+	id := uuid.New()
+	if actx, ok := model.ActorContextFromContext(ctx); ok {
+		id = actx.Self().ID
+	}
+
+	return &probeActor{id: id, probe: p}
+}
+
+// Address returns an address of the probe kind for tests that only need one.
+func (p *Probe) Address() model.Address {
+	return model.Address{Kind: p.kind, ID: p.id}
+}
+
+// Respond sets the function probe actors use to fill responses and choose the
+// error they return. By default they return nil and leave the response as is.
+func (p *Probe) Respond(fn func(ctx context.Context, msg proto.Message, res proto.Message) error) {
+	p.mx.Lock()
+	defer p.mx.Unlock()
+
+	p.respond = fn
+}
+
+// Len returns the number of recorded messages not yet taken by an expectation.
+func (p *Probe) Len() int {
+	p.mx.Lock()
+	defer p.mx.Unlock()
+
+	return len(p.received)
+}
+
+// Receive takes the oldest recorded message, waiting up to within for one.
+func (p *Probe) Receive(within time.Duration) Received {
+	p.tb.Helper()
+
+	received, ok := p.next(within)
+	if !ok {
+		p.tb.Fatalf("no message received by %s within %s", p.kind, within)
+	}
+
+	return received
+}
+
+// ExpectMessage takes the oldest recorded message and requires it to equal
+// expected.
+func (p *Probe) ExpectMessage(expected proto.Message, within time.Duration) Received {
+	p.tb.Helper()
+
+	received := p.Receive(within)
+	require.True(p.tb, proto.Equal(expected, received.Message), "expected %v, received %v", expected, received.Message)
+
+	return received
+}
+
+// ExpectNoMessage requires that nothing is recorded within the given time.
+func (p *Probe) ExpectNoMessage(within time.Duration) {
+	p.tb.Helper()
+
+	if received, ok := p.next(within); ok {
+		p.tb.Fatalf("unexpected message %v received by %s", received.Message, p.kind)
+	}
+}
+
+// ExpectMessageOf takes the oldest message recorded by p and requires it to be
+// of type T.
+func ExpectMessageOf[T proto.Message](p *Probe, within time.Duration) T {
+	p.tb.Helper()
+
+	received := p.Receive(within)
+	msg, ok := received.Message.(T)
+	require.True(p.tb, ok, "unexpected message type %T", received.Message)
+
+	return msg
+}
+
+func (p *Probe) next(within time.Duration) (Received, bool) {
+	timeout := time.NewTimer(within)
+	defer timeout.Stop()
+
+	for {
+		p.mx.Lock()
+		if len(p.received) > 0 {
+			received := p.received[0]
+			p.received = p.received[1:]
+			p.mx.Unlock()
+
+			return received, true
+		}
+		p.mx.Unlock()
+
+		select {
+		case <-p.changed:
+		case <-timeout.C:
+			return Received{}, false
+		}
+	}
+}
+
+func (p *Probe) record(ctx context.Context, received Received) error {
+	p.mx.Lock()
+	respond := p.respond
+	p.mx.Unlock()
+
+	var err error
+	if respond != nil {
+		err = respond(ctx, received.Message, received.Response)
+	}
+
+	p.mx.Lock()
+	p.received = append(p.received, received)
+	p.mx.Unlock()
+
+	select {
+	case p.changed <- struct{}{}:
+	default:
+	}
+
+	return err
+}
+
+type probeActor struct {
+	id    uuid.UUID
+	probe *Probe
+}
+
+func (a *probeActor) GetID() uuid.UUID            { return a.id }
+func (a *probeActor) GetKind() string             { return a.probe.kind }
+func (a *probeActor) Start(ctx context.Context)   {}
+func (a *probeActor) Destroy(ctx context.Context) {}
+func (a *probeActor) Receive(ctx context.Context, msg proto.Message, res proto.Message) error {
+	envelope, _ := model.EnvelopeFromContext(ctx)
+
+	return a.probe.record(ctx, Received{
+		Address:  model.Address{Kind: a.probe.kind, ID: a.id},
+		Envelope: envelope,
+		Message:  msg,
+		Response: res,
+	})
+}
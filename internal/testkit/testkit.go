@@ -0,0 +1,149 @@
+package testkit
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gnarloqgames/ga-actor-poc/internal/clock"
+	"github.com/gnarloqgames/ga-actor-poc/internal/manager"
+	"github.com/gnarloqgames/ga-actor-poc/internal/model"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+)
+
+// DefaultTimeout bounds every wait on real time done by the kit. Fake clocks
+// never need it to be long, it only covers goroutine handoffs.
+const DefaultTimeout = time.Second
+
+// Epoch is the time every fake clock created by the kit starts at.
+var Epoch = time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+type Factory func(ctx context.Context) model.Actor
+
+// Kit is a manager running on a fake clock, scoped to a single test. Every
+// actor it activates is destroyed when the test ends.
+type Kit struct {
+	Manager *manager.Manager
+	Clock   *clock.Fake
+
+	tb      testing.TB
+	mx      *sync.Mutex
+	started []model.Actor
+}
+
+func New(tb testing.TB) *Kit {
+	tb.Helper()
+
+	fake := clock.NewFake(Epoch)
+	k := &Kit{
+		Manager: manager.NewManagerWithClock(fake),
+		Clock:   fake,
+
+		tb:      tb,
+		mx:      &sync.Mutex{},
+		started: make([]model.Actor, 0),
+	}
+	tb.Cleanup(k.destroy)
+
+	return k
+}
+
+// Register adds a kind to the manager of the kit.
+func (k *Kit) Register(kind string, factory Factory) {
+	k.tb.Helper()
+
+	err := k.Manager.NewKind(kind, func(ctx context.Context) model.Actor {
+		actor := factory(ctx)
+
+		k.mx.Lock()
+		k.started = append(k.started, actor)
+		k.mx.Unlock()
+
+		return actor
+	})
+	require.NoError(k.tb, err)
+}
+
+// Probe registers kind as a probe. Every actor of that kind records what it
+// receives on the returned probe.
+func (k *Kit) Probe(kind string) *Probe {
+	k.tb.Helper()
+
+	probe := NewProbe(k.tb, kind)
+	k.Register(kind, probe.Factory)
+
+	return probe
+}
+
+// Actor returns the actor at address, activating it if needed.
+func (k *Kit) Actor(address model.Address) model.Actor {
+	k.tb.Helper()
+
+	actor, err := k.Manager.Actor(address)
+	require.NoError(k.tb, err)
+
+	return actor
+}
+
+// Send delivers msg to address and fails the test if delivery fails.
+func (k *Kit) Send(address model.Address, msg proto.Message, opts ...model.SendOption) {
+	k.tb.Helper()
+
+	err := k.Manager.Send(context.Background(), address, msg, DefaultTimeout, opts...)
+	require.NoError(k.tb, err)
+}
+
+// Ask delivers msg to address, fills res with the response and fails the test
+// if the actor returns an error.
+func (k *Kit) Ask(address model.Address, msg proto.Message, res proto.Message, opts ...model.SendOption) {
+	k.tb.Helper()
+
+	err := k.Manager.Ask(context.Background(), address, msg, res, DefaultTimeout, opts...)
+	require.NoError(k.tb, err)
+}
+
+// Advance moves the clock of the kit forward, firing every due timer.
+func (k *Kit) Advance(d time.Duration) {
+	k.Clock.Advance(d)
+}
+
+func (k *Kit) destroy() {
+	k.mx.Lock()
+	started := k.started
+	k.started = nil
+	k.mx.Unlock()
+
+	for _, actor := range started {
+		actor.Destroy(context.Background())
+	}
+}
+
+// Spawn registers factory as kind on a kit of its own and activates a single
+// actor of that kind with a random ID. Messages the actor sends to itself,
+// including through its timers, reach it, but there is no other actor to talk
+// to. The actor is destroyed when the test ends.
+func Spawn[T model.Actor](tb testing.TB, kind string, factory Factory) (T, *clock.Fake) {
+	tb.Helper()
+
+	kit := New(tb)
+	kit.Register(kind, factory)
+
+	actor, ok := kit.Actor(model.Address{Kind: kind, ID: uuid.New()}).(T)
+	require.True(tb, ok, "factory returned an unexpected actor type")
+
+	return actor, kit.Clock
+}
+
+// AwaitTimers waits until at least n timers are pending on fake. Actors that
+// start timers from their own goroutines must be awaited before advancing the
+// clock past them.
+func AwaitTimers(tb testing.TB, fake *clock.Fake, n int) {
+	tb.Helper()
+
+	require.Eventually(tb, func() bool {
+		return fake.Len() >= n
+	}, DefaultTimeout, time.Millisecond, "%d timers not registered", n)
+}
@@ -0,0 +1,107 @@
+package testkit
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/gnarloqgames/ga-actor-poc/internal/actor"
+	"github.com/gnarloqgames/ga-actor-poc/internal/manager"
+	"github.com/gnarloqgames/ga-actor-poc/internal/model"
+	"github.com/gnarloqgames/ga-actor-poc/message"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+func TestProbe(t *testing.T) {
+	kit := New(t)
+	probe := kit.Probe("probe")
+	other := model.Address{Kind: "probe", ID: uuid.New()}
+
+	kit.Send(probe.Address(), wrapperspb.String("first"))
+	kit.Send(other, wrapperspb.String("second"))
+
+	received := probe.ExpectMessage(wrapperspb.String("first"), DefaultTimeout)
+	require.Equal(t, probe.Address(), received.Address)
+	require.Equal(t, probe.Address().Message().ID, received.Envelope.Recipient.ID)
+
+	msg := ExpectMessageOf[*wrapperspb.StringValue](probe, DefaultTimeout)
+	require.Equal(t, "second", msg.Value)
+
+	probe.ExpectNoMessage(10 * time.Millisecond)
+}
+
+func TestProbeRespond(t *testing.T) {
+	kit := New(t)
+	probe := kit.Probe("probe")
+	probe.Respond(func(ctx context.Context, msg proto.Message, res proto.Message) error {
+		if msg.(*wrapperspb.StringValue).Value == "fail" {
+			return errors.New("failed")
+		}
+
+		res.(*wrapperspb.StringValue).Value = "pong"
+
+		return nil
+	})
+
+	res := &wrapperspb.StringValue{}
+	kit.Ask(probe.Address(), wrapperspb.String("ping"), res)
+	require.Equal(t, "pong", res.Value)
+
+	err := kit.Manager.Send(context.Background(), probe.Address(), wrapperspb.String("fail"), DefaultTimeout)
+	require.Error(t, err)
+	require.Equal(t, 2, probe.Len())
+}
+
+func TestKitSchedule(t *testing.T) {
+	kit := New(t)
+	probe := kit.Probe("probe")
+
+	_, err := kit.Manager.Schedule(probe.Address(), manager.Every(time.Hour), wrapperspb.String("tick"), manager.ScheduleOptions{})
+	require.NoError(t, err)
+
+	kit.Advance(59 * time.Minute)
+	probe.ExpectNoMessage(0)
+
+	kit.Advance(time.Minute)
+	probe.ExpectMessage(wrapperspb.String("tick"), DefaultTimeout)
+}
+
+func TestKitInventory(t *testing.T) {
+	kit := New(t)
+	kit.Register("inventory", actor.InventoryActorFactory)
+	address := model.Address{Kind: "inventory", ID: uuid.New()}
+
+	res := &message.BuildResponse{}
+	kit.Ask(address, &message.BuildRequest{TraceID: "trace", Name: "farm", Duration: "12h"}, res)
+	require.Equal(t, "trace", res.TraceID)
+
+	inventory := kit.Actor(address).(*actor.InventoryActor)
+	AwaitTimers(t, kit.Clock, 1)
+
+	kit.Advance(12 * time.Hour)
+
+	require.Eventually(t, func() bool {
+		return inventory.Buildings.Len() == 1
+	}, DefaultTimeout, time.Millisecond)
+}
+
+func TestSpawn(t *testing.T) {
+	inventory, fake := Spawn[*actor.InventoryActor](t, "inventory", actor.InventoryActorFactory)
+
+	require.Equal(t, Epoch, inventory.Clock.Now())
+
+	// Builds are timed with messages the inventory sends to itself.
+	err := inventory.Receive(context.Background(), &message.BuildRequest{Name: "farm", Duration: "1h"}, nil)
+	require.NoError(t, err)
+	AwaitTimers(t, fake, 1)
+
+	fake.Advance(time.Hour)
+	require.Equal(t, Epoch.Add(time.Hour), inventory.Clock.Now())
+	require.Eventually(t, func() bool {
+		return inventory.Buildings.Len() == 1
+	}, DefaultTimeout, time.Millisecond)
+}
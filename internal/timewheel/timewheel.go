@@ -0,0 +1,342 @@
+package timewheel
+
+import (
+	"sync"
+	"time"
+)
+
+const (
+	DefaultTick   = 10 * time.Millisecond
+	DefaultSlots  = 64
+	DefaultLevels = 6
+)
+
+// Default is the wheel shared by every timer that is not given its own. With
+// the default settings it spans roughly 21 years at 10ms resolution.
+var Default = New(DefaultTick, DefaultSlots, DefaultLevels)
+
+// Wheel is a hierarchical timing wheel. Level 0 has slots of one tick, every
+// following level has slots as wide as the whole level below it. Timers are
+// cascaded down a level each time the wheel passes their slot, so a single
+// goroutine can drive any number of timers.
+type Wheel struct {
+	mx *sync.Mutex
+
+	tick   time.Duration
+	slots  int
+	levels [][]bucket
+
+	start time.Time
+	now   uint64
+	count int
+
+	wake chan struct{}
+	stop chan struct{}
+}
+
+type bucket struct {
+	head *Timer
+}
+
+func (b *bucket) push(t *Timer) {
+	t.bucket = b
+	t.prev = nil
+	t.next = b.head
+	if b.head != nil {
+		b.head.prev = t
+	}
+	b.head = t
+}
+
+func (b *bucket) remove(t *Timer) {
+	if t.prev != nil {
+		t.prev.next = t.next
+	} else {
+		b.head = t.next
+	}
+	if t.next != nil {
+		t.next.prev = t.prev
+	}
+
+	t.bucket = nil
+	t.prev = nil
+	t.next = nil
+}
+
+// take empties the bucket and returns its timers as a list linked by next.
+func (b *bucket) take() *Timer {
+	head := b.head
+	b.head = nil
+
+	return head
+}
+
+// Timer is a callback registered with a Wheel.
+type Timer struct {
+	w *Wheel
+
+	expires uint64
+	fn      func()
+
+	bucket *bucket
+	prev   *Timer
+	next   *Timer
+}
+
+// New creates a wheel with the given resolution and shape and starts the
+// goroutine driving it.
+func New(tick time.Duration, slots int, levels int) *Wheel {
+	w := &Wheel{
+		mx: &sync.Mutex{},
+
+		tick:   tick,
+		slots:  slots,
+		levels: make([][]bucket, levels),
+
+		start: time.Now(),
+
+		wake: make(chan struct{}, 1),
+		stop: make(chan struct{}),
+	}
+	for i := range w.levels {
+		w.levels[i] = make([]bucket, slots)
+	}
+
+	go w.run()
+
+	return w
+}
+
+// Stop halts the wheel. Pending timers never fire.
+func (w *Wheel) Stop() {
+	close(w.stop)
+}
+
+// Len returns the number of pending timers.
+func (w *Wheel) Len() int {
+	w.mx.Lock()
+	defer w.mx.Unlock()
+
+	return w.count
+}
+
+// AfterFunc calls fn in its own goroutine once d has elapsed.
+func (w *Wheel) AfterFunc(d time.Duration, fn func()) *Timer {
+	t := &Timer{
+		w:  w,
+		fn: fn,
+	}
+
+	w.mx.Lock()
+	defer w.mx.Unlock()
+
+	w.schedule(t, d)
+
+	return t
+}
+
+// Stop prevents the timer from firing. It reports false if the timer has
+// already fired or been stopped.
+func (t *Timer) Stop() bool {
+	t.w.mx.Lock()
+	defer t.w.mx.Unlock()
+
+	if t.bucket == nil {
+		return false
+	}
+
+	t.w.unlink(t)
+
+	return true
+}
+
+// Reschedule makes a pending timer fire after d, counting from now. It reports
+// false if the timer has already fired or been stopped.
+func (t *Timer) Reschedule(d time.Duration) bool {
+	t.w.mx.Lock()
+	defer t.w.mx.Unlock()
+
+	if t.bucket == nil {
+		return false
+	}
+
+	t.w.unlink(t)
+	t.w.schedule(t, d)
+
+	return true
+}
+
+// Remaining returns how long until a pending timer fires, or zero if it has
+// fired or been stopped.
+func (t *Timer) Remaining() time.Duration {
+	t.w.mx.Lock()
+	defer t.w.mx.Unlock()
+
+	if t.bucket == nil {
+		return 0
+	}
+
+	return max(t.w.timeOf(t.expires)-time.Now().Sub(t.w.start), 0)
+}
+
+// ticksAt returns the number of whole ticks between the start of the wheel
+// and at, rounded down, or up if roundUp is set.
+func (w *Wheel) ticksAt(at time.Time, roundUp bool) uint64 {
+	elapsed := at.Sub(w.start)
+	if elapsed <= 0 {
+		return 0
+	}
+	if roundUp {
+		elapsed += w.tick - 1
+	}
+
+	return uint64(elapsed / w.tick)
+}
+
+func (w *Wheel) timeOf(tick uint64) time.Duration {
+	return time.Duration(tick) * w.tick
+}
+
+// schedule places t in the wheel or fires it right away if it is already due.
+// The caller must hold w.mx.
+func (w *Wheel) schedule(t *Timer, d time.Duration) {
+	now := time.Now()
+	if w.count == 0 {
+		// Nothing drove the wheel while it was empty, catch up without
+		// stepping through every idle tick.
+		w.now = max(w.now, w.ticksAt(now, false))
+	}
+
+	t.expires = w.ticksAt(now.Add(d), true)
+
+	if t.expires <= w.now {
+		go t.fn()
+		return
+	}
+
+	w.place(t)
+	w.count++
+
+	if w.count == 1 {
+		select {
+		case w.wake <- struct{}{}:
+		default:
+		}
+	}
+}
+
+// place puts t in the bucket of the lowest level whose span covers its
+// expiration. The caller must hold w.mx.
+func (w *Wheel) place(t *Timer) {
+	delta := t.expires - w.now
+	span := uint64(w.slots)
+	width := uint64(1)
+
+	for level := range w.levels {
+		if delta < span || level == len(w.levels)-1 {
+			slot := t.expires / width
+			if delta >= span {
+				slot = w.now / width
+			}
+
+			w.levels[level][slot%uint64(w.slots)].push(t)
+			return
+		}
+
+		width = span
+		span *= uint64(w.slots)
+	}
+}
+
+// unlink removes a pending t from its bucket. The caller must hold w.mx.
+func (w *Wheel) unlink(t *Timer) {
+	t.bucket.remove(t)
+	w.count--
+}
+
+// advance moves the wheel forward by one tick, cascading higher levels and
+// firing every timer that has become due. The caller must hold w.mx.
+func (w *Wheel) advance() {
+	w.now++
+
+	width := uint64(1)
+	for level := range w.levels {
+		if level > 0 {
+			if w.now%width != 0 {
+				break
+			}
+
+			for t := w.levels[level][(w.now/width)%uint64(w.slots)].take(); t != nil; {
+				next := t.next
+				t.bucket = nil
+				t.prev = nil
+				t.next = nil
+
+				if t.expires <= w.now {
+					w.count--
+					go t.fn()
+				} else {
+					w.place(t)
+				}
+
+				t = next
+			}
+		}
+
+		width *= uint64(w.slots)
+	}
+
+	for t := w.levels[0][w.now%uint64(w.slots)].take(); t != nil; {
+		next := t.next
+		t.bucket = nil
+		t.prev = nil
+		t.next = nil
+
+		w.count--
+		go t.fn()
+
+		t = next
+	}
+}
+
+func (w *Wheel) run() {
+	ticker := time.NewTimer(w.tick)
+	defer ticker.Stop()
+
+	for {
+		w.mx.Lock()
+		idle := w.count == 0
+		w.mx.Unlock()
+
+		if idle {
+			select {
+			case <-w.wake:
+			case <-w.stop:
+				return
+			}
+		}
+
+		w.mx.Lock()
+		target := w.ticksAt(time.Now(), false)
+		for w.now < target {
+			w.advance()
+		}
+		next := w.timeOf(w.now+1) - time.Since(w.start)
+		w.mx.Unlock()
+
+		ticker.Reset(max(next, 0))
+
+		select {
+		case <-ticker.C:
+		case <-w.wake:
+			if !ticker.Stop() {
+				select {
+				case <-ticker.C:
+				default:
+				}
+			}
+		case <-w.stop:
+			return
+		}
+	}
+}
@@ -0,0 +1,232 @@
+package timewheel
+
+import (
+	"runtime"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestAfterFunc(t *testing.T) {
+	tests := []struct {
+		label    string
+		slots    int
+		levels   int
+		duration time.Duration
+	}{
+		{
+			label:    "level 0",
+			slots:    8,
+			levels:   3,
+			duration: 5 * time.Millisecond,
+		},
+		{
+			label:    "cascaded",
+			slots:    4,
+			levels:   3,
+			duration: 30 * time.Millisecond,
+		},
+		{
+			label:    "overflow",
+			slots:    2,
+			levels:   2,
+			duration: 20 * time.Millisecond,
+		},
+		{
+			label:    "immediate",
+			slots:    4,
+			levels:   2,
+			duration: 0,
+		},
+	}
+
+	for _, tt := range tests {
+		tf := func(t *testing.T) {
+			w := New(time.Millisecond, tt.slots, tt.levels)
+			defer w.Stop()
+
+			fired := make(chan time.Time, 1)
+			start := time.Now()
+			w.AfterFunc(tt.duration, func() { fired <- time.Now() })
+
+			select {
+			case at := <-fired:
+				require.GreaterOrEqual(t, at.Sub(start), tt.duration)
+			case <-time.After(tt.duration + time.Second):
+				t.Fatal("timer did not fire")
+			}
+			require.Equal(t, 0, w.Len())
+		}
+
+		t.Run(tt.label, tf)
+	}
+}
+
+func TestOrder(t *testing.T) {
+	w := New(time.Millisecond, 4, 3)
+	defer w.Stop()
+
+	mx := &sync.Mutex{}
+	order := make([]int, 0)
+	done := make(chan struct{})
+
+	for _, i := range []int{5, 1, 3, 2, 4} {
+		w.AfterFunc(time.Duration(i)*10*time.Millisecond, func() {
+			mx.Lock()
+			defer mx.Unlock()
+
+			order = append(order, i)
+			if len(order) == 5 {
+				close(done)
+			}
+		})
+	}
+
+	<-done
+	require.Equal(t, []int{1, 2, 3, 4, 5}, order)
+}
+
+func TestStop(t *testing.T) {
+	w := New(time.Millisecond, 4, 3)
+	defer w.Stop()
+
+	fired := make(chan struct{}, 1)
+	timer := w.AfterFunc(20*time.Millisecond, func() { fired <- struct{}{} })
+
+	require.True(t, timer.Stop())
+	require.False(t, timer.Stop())
+	require.False(t, timer.Reschedule(time.Millisecond))
+	require.Equal(t, 0, w.Len())
+
+	select {
+	case <-fired:
+		t.Fatal("stopped timer fired")
+	case <-time.After(50 * time.Millisecond):
+	}
+}
+
+func TestReschedule(t *testing.T) {
+	w := New(time.Millisecond, 4, 3)
+	defer w.Stop()
+
+	fired := make(chan struct{}, 1)
+	timer := w.AfterFunc(time.Hour, func() { fired <- struct{}{} })
+	require.Greater(t, timer.Remaining(), 59*time.Minute)
+
+	require.True(t, timer.Reschedule(5*time.Millisecond))
+	require.LessOrEqual(t, timer.Remaining(), 6*time.Millisecond)
+
+	select {
+	case <-fired:
+	case <-time.After(time.Second):
+		t.Fatal("rescheduled timer did not fire")
+	}
+	require.False(t, timer.Reschedule(time.Millisecond))
+	require.Equal(t, time.Duration(0), timer.Remaining())
+}
+
+// goroutineTimer is the one-goroutine-per-timer design the wheel replaces.
+func goroutineTimer(d time.Duration, fn func(), stop chan struct{}) {
+	go func() {
+		timer := time.NewTimer(d)
+		select {
+		case <-timer.C:
+			fn()
+		case <-stop:
+			timer.Stop()
+		}
+	}()
+}
+
+func heapAndStack() uint64 {
+	runtime.GC()
+
+	var stats runtime.MemStats
+	runtime.ReadMemStats(&stats)
+
+	return stats.HeapAlloc + stats.StackInuse
+}
+
+// BenchmarkPending reports the memory held per pending timer.
+func BenchmarkPending(b *testing.B) {
+	b.Run("wheel", func(b *testing.B) {
+		w := New(DefaultTick, DefaultSlots, DefaultLevels)
+		defer w.Stop()
+
+		timers := make([]*Timer, b.N)
+		before := heapAndStack()
+		b.ResetTimer()
+
+		for i := range timers {
+			timers[i] = w.AfterFunc(time.Hour, func() {})
+		}
+
+		b.StopTimer()
+		b.ReportMetric(float64(heapAndStack()-before)/float64(b.N), "bytes/timer")
+		for _, timer := range timers {
+			timer.Stop()
+		}
+	})
+
+	b.Run("goroutine", func(b *testing.B) {
+		stop := make(chan struct{})
+		defer close(stop)
+
+		before := heapAndStack()
+		b.ResetTimer()
+
+		for i := 0; i < b.N; i++ {
+			goroutineTimer(time.Hour, func() {}, stop)
+		}
+
+		b.StopTimer()
+		b.ReportMetric(float64(heapAndStack()-before)/float64(b.N), "bytes/timer")
+	})
+}
+
+// BenchmarkLatency reports how late timers fire on average while 10k other
+// timers are pending.
+func BenchmarkLatency(b *testing.B) {
+	const pending = 10000
+	const d = 20 * time.Millisecond
+
+	measure := func(b *testing.B, schedule func(fn func())) {
+		lateness := make(chan time.Duration, b.N)
+		b.ResetTimer()
+
+		for i := 0; i < b.N; i++ {
+			start := time.Now()
+			schedule(func() { lateness <- time.Since(start) - d })
+		}
+
+		var total time.Duration
+		for i := 0; i < b.N; i++ {
+			total += <-lateness
+		}
+
+		b.StopTimer()
+		b.ReportMetric(float64(total)/float64(b.N), "late-ns/timer")
+	}
+
+	b.Run("wheel", func(b *testing.B) {
+		w := New(time.Millisecond, DefaultSlots, DefaultLevels)
+		defer w.Stop()
+		for i := 0; i < pending; i++ {
+			w.AfterFunc(time.Hour, func() {})
+		}
+
+		measure(b, func(fn func()) { w.AfterFunc(d, fn) })
+	})
+
+	b.Run("goroutine", func(b *testing.B) {
+		stop := make(chan struct{})
+		defer close(stop)
+		for i := 0; i < pending; i++ {
+			goroutineTimer(time.Hour, func() {}, stop)
+		}
+
+		measure(b, func(fn func()) { goroutineTimer(d, fn, stop) })
+	})
+}
@@ -0,0 +1,162 @@
+package trace
+
+import (
+	"cmp"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"os"
+	"slices"
+	"sync"
+	"time"
+)
+
+const (
+	StatusUnset = 0
+	StatusError = 2
+)
+
+// Attribute is a span attribute in the OTLP JSON encoding.
+type Attribute struct {
+	Key   string         `json:"key"`
+	Value AttributeValue `json:"value"`
+}
+
+type AttributeValue struct {
+	StringValue string `json:"stringValue"`
+}
+
+type Status struct {
+	Code    int    `json:"code"`
+	Message string `json:"message,omitempty"`
+}
+
+// SpanData is an ended span, encoded to JSON the way OTLP encodes spans.
+type SpanData struct {
+	TraceID           string      `json:"traceId"`
+	SpanID            string      `json:"spanId"`
+	ParentSpanID      string      `json:"parentSpanId,omitempty"`
+	Name              string      `json:"name"`
+	StartTimeUnixNano uint64      `json:"startTimeUnixNano,string"`
+	EndTimeUnixNano   uint64      `json:"endTimeUnixNano,string"`
+	Attributes        []Attribute `json:"attributes,omitempty"`
+	Status            Status      `json:"status"`
+}
+
+// Attribute returns the value of the attribute named key.
+func (d SpanData) Attribute(key string) (string, bool) {
+	i := slices.IndexFunc(d.Attributes, func(a Attribute) bool { return a.Key == key })
+	if i < 0 {
+		return "", false
+	}
+
+	return d.Attributes[i].Value.StringValue, true
+}
+
+// data snapshots the span. The caller must hold s.mx.
+func (s *Span) data(end time.Time) SpanData {
+	data := SpanData{
+		TraceID:           s.context.TraceID.String(),
+		SpanID:            s.context.SpanID.String(),
+		Name:              s.name,
+		StartTimeUnixNano: uint64(s.start.UnixNano()),
+		EndTimeUnixNano:   uint64(end.UnixNano()),
+		Attributes:        make([]Attribute, 0, len(s.attributes)),
+	}
+	if s.parent.IsValid() {
+		data.ParentSpanID = s.parent.String()
+	}
+	if s.err != nil {
+		data.Status = Status{Code: StatusError, Message: s.err.Error()}
+	}
+
+	for key, value := range s.attributes {
+		data.Attributes = append(data.Attributes, Attribute{Key: key, Value: AttributeValue{StringValue: value}})
+	}
+	slices.SortFunc(data.Attributes, func(a, b Attribute) int { return cmp.Compare(a.Key, b.Key) })
+
+	return data
+}
+
+func (t *Tracer) export(data SpanData) {
+	if err := t.exporter.Export(data); err != nil {
+		slog.Error("failed to export span",
+			"trace_id", data.TraceID,
+			"span_id", data.SpanID,
+			"error", err,
+		)
+	}
+}
+
+// Exporter receives every sampled span once it ends.
+type Exporter interface {
+	Export(span SpanData) error
+}
+
+// MemoryExporter keeps spans in memory, standing in for a collector.
+type MemoryExporter struct {
+	mx    *sync.Mutex
+	spans []SpanData
+}
+
+func NewMemoryExporter() *MemoryExporter {
+	return &MemoryExporter{
+		mx:    &sync.Mutex{},
+		spans: make([]SpanData, 0),
+	}
+}
+
+func (e *MemoryExporter) Export(span SpanData) error {
+	e.mx.Lock()
+	defer e.mx.Unlock()
+
+	e.spans = append(e.spans, span)
+
+	return nil
+}
+
+// Spans returns the exported spans in the order they ended.
+func (e *MemoryExporter) Spans() []SpanData {
+	e.mx.Lock()
+	defer e.mx.Unlock()
+
+	return slices.Clone(e.spans)
+}
+
+// FileExporter appends spans to a file, one JSON object per line.
+type FileExporter struct {
+	mx      *sync.Mutex
+	file    *os.File
+	encoder *json.Encoder
+}
+
+func NewFileExporter(path string) (*FileExporter, error) {
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o644)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open span file: %w", err)
+	}
+
+	return &FileExporter{
+		mx:      &sync.Mutex{},
+		file:    file,
+		encoder: json.NewEncoder(file),
+	}, nil
+}
+
+func (e *FileExporter) Export(span SpanData) error {
+	e.mx.Lock()
+	defer e.mx.Unlock()
+
+	if err := e.encoder.Encode(span); err != nil {
+		return fmt.Errorf("failed to write span: %w", err)
+	}
+
+	return nil
+}
+
+func (e *FileExporter) Close() error {
+	e.mx.Lock()
+	defer e.mx.Unlock()
+
+	return e.file.Close()
+}
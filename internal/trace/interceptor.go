@@ -0,0 +1,145 @@
+package trace
+
+import (
+	"context"
+	"time"
+
+	"github.com/gnarloqgames/ga-actor-poc/internal/manager"
+	"github.com/gnarloqgames/ga-actor-poc/internal/model"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+const (
+	// HeaderTraceparent carries the span of the send of a message, in the
+	// W3C Trace Context format, so that any hop can continue the trace.
+	HeaderTraceparent string = "traceparent"
+	// HeaderEnqueuedAt carries the time a message was sent.
+	HeaderEnqueuedAt string = "enqueued_at"
+
+	// FieldTraceID is the message field trace IDs are read from and
+	// written to, as on message.BuildRequest.
+	FieldTraceID protoreflect.Name = "TraceID"
+
+	AttributeRecipientKind string = "recipient_kind"
+	AttributeRecipientID   string = "recipient_id"
+	AttributeActorKind     string = "actor_kind"
+	AttributeActorID       string = "actor_id"
+	AttributeCorrelationID string = "correlation_id"
+	AttributeMessage       string = "message"
+)
+
+// Interceptor traces every delivery with three spans: "enqueue" around the
+// send, "wait" from the send until the recipient starts handling the message
+// and "handle" around Receive. A send joins the span found in its context,
+// else the trace of the message it is sent while handling, through the
+// headers of the envelope, else the trace named by the TraceID field of the
+// message. A send starting a new trace delivers a copy of the message with its
+// empty TraceID field filled, leaving the message of the caller as it is. A
+// receive without a traceparent header joins the trace named by the TraceID
+// field.
+func Interceptor(t *Tracer) manager.Interceptor {
+	return manager.Interceptor{
+		Send: func(next manager.SendHandler) manager.SendHandler {
+			return func(ctx context.Context, d *manager.Delivery) error {
+				name := string(d.Message.ProtoReflect().Descriptor().FullName())
+
+				ctx, span := t.Start(ctx, "enqueue "+name, parentOf(ctx, d))
+				defer span.End()
+
+				span.SetAttribute(AttributeRecipientKind, d.Address.Kind)
+				span.SetAttribute(AttributeRecipientID, d.Address.ID.String())
+				span.SetAttribute(AttributeCorrelationID, d.Envelope.GetCorrelationID())
+				span.SetAttribute(AttributeMessage, name)
+
+				d.Message = withTraceID(d.Message, span.Context().TraceID)
+				d.Envelope.Headers[HeaderTraceparent] = span.Context().Traceparent()
+				d.Envelope.Headers[HeaderEnqueuedAt] = t.Now().Format(time.RFC3339Nano)
+
+				err := next(ctx, d)
+				span.SetError(err)
+
+				return err
+			}
+		},
+		Receive: func(next manager.ReceiveHandler) manager.ReceiveHandler {
+			return func(ctx context.Context, actor model.Actor, d *manager.Delivery) error {
+				name := string(d.Message.ProtoReflect().Descriptor().FullName())
+				parent := receivedParent(d)
+
+				if enqueuedAt, err := time.Parse(time.RFC3339Nano, d.Envelope.GetHeaders()[HeaderEnqueuedAt]); err == nil {
+					_, wait := t.StartAt(ctx, "wait "+name, parent, enqueuedAt)
+					wait.End()
+				}
+
+				ctx, span := t.Start(ctx, "handle "+name, parent)
+				defer span.End()
+
+				span.SetAttribute(AttributeActorKind, actor.GetKind())
+				span.SetAttribute(AttributeActorID, actor.GetID().String())
+				span.SetAttribute(AttributeCorrelationID, d.Envelope.GetCorrelationID())
+				span.SetAttribute(AttributeMessage, name)
+
+				err := next(ctx, actor, d)
+				span.SetError(err)
+
+				return err
+			}
+		},
+	}
+}
+
+func parentOf(ctx context.Context, d *manager.Delivery) SpanContext {
+	if span, ok := SpanFromContext(ctx); ok {
+		return span.Context()
+	}
+
+	return receivedParent(d)
+}
+
+// receivedParent returns the span in the traceparent header of d, else the
+// trace named by the TraceID field of its message.
+func receivedParent(d *manager.Delivery) SpanContext {
+	if parent, err := ParseTraceparent(d.Envelope.GetHeaders()[HeaderTraceparent]); err == nil {
+		return parent
+	}
+
+	if value := traceID(d.Message); value != "" {
+		return SpanContext{TraceID: TraceIDFrom(value)}
+	}
+
+	return SpanContext{}
+}
+
+func traceIDField(msg proto.Message) (protoreflect.Message, protoreflect.FieldDescriptor) {
+	m := msg.ProtoReflect()
+	fd := m.Descriptor().Fields().ByName(FieldTraceID)
+	if fd == nil || fd.Kind() != protoreflect.StringKind || fd.IsList() {
+		return nil, nil
+	}
+
+	return m, fd
+}
+
+func traceID(msg proto.Message) string {
+	m, fd := traceIDField(msg)
+	if fd == nil {
+		return ""
+	}
+
+	return m.Get(fd).String()
+}
+
+// withTraceID returns a copy of msg whose TraceID field is set to id, or msg
+// itself if it has no such field or the field is already set.
+func withTraceID(msg proto.Message, id TraceID) proto.Message {
+	m, fd := traceIDField(msg)
+	if fd == nil || m.Get(fd).String() != "" {
+		return msg
+	}
+
+	msg = proto.Clone(msg)
+	msg.ProtoReflect().Set(fd, protoreflect.ValueOfString(id.String()))
+
+	return msg
+}
@@ -0,0 +1,157 @@
+package trace
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/gnarloqgames/ga-actor-poc/internal/manager"
+	"github.com/gnarloqgames/ga-actor-poc/internal/model"
+	"github.com/gnarloqgames/ga-actor-poc/internal/testkit"
+	"github.com/gnarloqgames/ga-actor-poc/message"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+func newTracedKit(t *testing.T) (*testkit.Kit, *MemoryExporter) {
+	t.Helper()
+
+	kit := testkit.New(t)
+	exporter := NewMemoryExporter()
+	tracer := NewTracer(exporter, 1)
+	tracer.UseClock(kit.Clock)
+	kit.Manager.Use(Interceptor(tracer))
+
+	return kit, exporter
+}
+
+func spansByName(spans []SpanData) map[string]SpanData {
+	byName := make(map[string]SpanData, len(spans))
+	for _, span := range spans {
+		byName[span.Name] = span
+	}
+
+	return byName
+}
+
+func TestInterceptor(t *testing.T) {
+	kit, exporter := newTracedKit(t)
+	probe := kit.Probe("probe")
+
+	req := &message.BuildRequest{Name: "farm"}
+	kit.Send(probe.Address(), req)
+	received := probe.Receive(testkit.DefaultTimeout)
+
+	spans := spansByName(exporter.Spans())
+	require.Len(t, spans, 3)
+	enqueue := spans["enqueue message.BuildRequest"]
+	wait := spans["wait message.BuildRequest"]
+	handle := spans["handle message.BuildRequest"]
+
+	require.Empty(t, req.TraceID, "the message of the caller is left as it is")
+	require.Equal(t, enqueue.TraceID, received.Message.(*message.BuildRequest).TraceID, "an empty TraceID is filled")
+	for _, span := range []SpanData{wait, handle} {
+		require.Equal(t, enqueue.TraceID, span.TraceID)
+		require.Equal(t, enqueue.SpanID, span.ParentSpanID)
+	}
+	require.Empty(t, enqueue.ParentSpanID)
+
+	sc, err := ParseTraceparent(received.Envelope.Headers[HeaderTraceparent])
+	require.NoError(t, err)
+	require.Equal(t, enqueue.SpanID, sc.SpanID.String())
+
+	actorID, _ := handle.Attribute(AttributeActorID)
+	require.Equal(t, probe.Address().ID.String(), actorID)
+}
+
+func TestInterceptorTraceID(t *testing.T) {
+	kit, exporter := newTracedKit(t)
+	probe := kit.Probe("probe")
+
+	req := &message.BuildRequest{TraceID: "order-1"}
+	kit.Send(probe.Address(), req)
+	probe.Receive(testkit.DefaultTimeout)
+
+	require.Equal(t, "order-1", req.TraceID)
+	for _, span := range exporter.Spans() {
+		require.Equal(t, TraceIDFrom("order-1").String(), span.TraceID)
+	}
+}
+
+func TestInterceptorReceiveTraceID(t *testing.T) {
+	exporter := NewMemoryExporter()
+	tracer := NewTracer(exporter, 1)
+	receive := Interceptor(tracer).Receive(func(ctx context.Context, actor model.Actor, d *manager.Delivery) error {
+		return nil
+	})
+
+	kit := testkit.New(t)
+	probe := kit.Probe("probe")
+	actor := kit.Actor(probe.Address())
+
+	err := receive(context.Background(), actor, &manager.Delivery{
+		Address:  probe.Address(),
+		Envelope: &message.Envelope{Headers: map[string]string{}},
+		Message:  &message.BuildRequest{TraceID: "order-1"},
+	})
+	require.NoError(t, err)
+
+	spans := exporter.Spans()
+	require.Len(t, spans, 1)
+	require.Equal(t, TraceIDFrom("order-1").String(), spans[0].TraceID, "a message without traceparent joins its TraceID")
+}
+
+func TestInterceptorPropagation(t *testing.T) {
+	kit, exporter := newTracedKit(t)
+	probe := kit.Probe("probe")
+	next := model.Address{Kind: "probe", ID: uuid.New()}
+
+	probe.Respond(func(ctx context.Context, msg proto.Message, res proto.Message) error {
+		actx, _ := model.ActorContextFromContext(ctx)
+
+		switch msg.(*wrapperspb.StringValue).Value {
+		case "relay":
+			return actx.Send(ctx, next, wrapperspb.String("relayed"), time.Second)
+		case "later":
+			_, err := actx.After(time.Hour, wrapperspb.String("fired"))
+			return err
+		}
+
+		return nil
+	})
+
+	kit.Send(probe.Address(), wrapperspb.String("relay"))
+	probe.Receive(testkit.DefaultTimeout)
+	probe.Receive(testkit.DefaultTimeout)
+
+	kit.Send(probe.Address(), wrapperspb.String("later"))
+	probe.Receive(testkit.DefaultTimeout)
+	kit.Advance(time.Hour)
+	probe.Receive(testkit.DefaultTimeout)
+
+	spans := exporter.Spans()
+	traces := make(map[string]int)
+	for _, span := range spans {
+		traces[span.TraceID]++
+	}
+	require.Len(t, traces, 2, "relayed and timer messages stay in the trace of their cause")
+	for _, count := range traces {
+		require.Equal(t, 6, count)
+	}
+
+	// The relayed message is sent while handling the first one.
+	byID := make(map[string]SpanData, len(spans))
+	for _, span := range spans {
+		byID[span.SpanID] = span
+	}
+	relayed := 0
+	for _, span := range spans {
+		if recipient, _ := span.Attribute(AttributeRecipientID); recipient == next.ID.String() {
+			require.Equal(t, "handle google.protobuf.StringValue", byID[span.ParentSpanID].Name)
+			relayed++
+		}
+	}
+	require.Equal(t, 1, relayed)
+}
@@ -0,0 +1,252 @@
+package trace
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gnarloqgames/ga-actor-poc/internal/clock"
+)
+
+var ErrInvalidTraceparent = errors.New("invalid traceparent")
+
+type TraceID [16]byte
+
+type SpanID [8]byte
+
+func (id TraceID) String() string { return hex.EncodeToString(id[:]) }
+func (id TraceID) IsValid() bool  { return id != TraceID{} }
+func (id SpanID) String() string  { return hex.EncodeToString(id[:]) }
+func (id SpanID) IsValid() bool   { return id != SpanID{} }
+
+// ParseTraceID reads a trace ID written as 32 hex digits.
+func ParseTraceID(s string) (TraceID, bool) {
+	var id TraceID
+	if len(s) != 32 {
+		return id, false
+	}
+	if _, err := hex.Decode(id[:], []byte(s)); err != nil {
+		return TraceID{}, false
+	}
+
+	return id, id.IsValid()
+}
+
+// TraceIDFrom returns the trace ID a TraceID field of a message stands for:
+// the ID itself when it is one, otherwise one derived from its value, so that
+// every message with the same value joins the same trace.
+func TraceIDFrom(value string) TraceID {
+	if id, ok := ParseTraceID(value); ok {
+		return id
+	}
+
+	var id TraceID
+	sum := sha256.Sum256([]byte(value))
+	copy(id[:], sum[:])
+
+	return id
+}
+
+// SpanContext identifies a span across actors and processes.
+type SpanContext struct {
+	TraceID TraceID
+	SpanID  SpanID
+	Sampled bool
+}
+
+func (sc SpanContext) IsValid() bool {
+	return sc.TraceID.IsValid() && sc.SpanID.IsValid()
+}
+
+// Traceparent formats sc as a W3C Trace Context traceparent header.
+func (sc SpanContext) Traceparent() string {
+	flags := "00"
+	if sc.Sampled {
+		flags = "01"
+	}
+
+	return fmt.Sprintf("00-%s-%s-%s", sc.TraceID, sc.SpanID, flags)
+}
+
+// ParseTraceparent reads a W3C Trace Context traceparent header.
+func ParseTraceparent(s string) (SpanContext, error) {
+	parts := strings.Split(s, "-")
+	if len(parts) != 4 || parts[0] != "00" || len(parts[2]) != 16 || len(parts[3]) != 2 {
+		return SpanContext{}, fmt.Errorf("%w: %q", ErrInvalidTraceparent, s)
+	}
+
+	var sc SpanContext
+	var ok bool
+	if sc.TraceID, ok = ParseTraceID(parts[1]); !ok {
+		return SpanContext{}, fmt.Errorf("%w: trace id %q", ErrInvalidTraceparent, parts[1])
+	}
+	if _, err := hex.Decode(sc.SpanID[:], []byte(parts[2])); err != nil || !sc.SpanID.IsValid() {
+		return SpanContext{}, fmt.Errorf("%w: span id %q", ErrInvalidTraceparent, parts[2])
+	}
+	flags, err := hex.DecodeString(parts[3])
+	if err != nil {
+		return SpanContext{}, fmt.Errorf("%w: flags %q", ErrInvalidTraceparent, parts[3])
+	}
+	sc.Sampled = flags[0]&1 == 1
+
+	return sc, nil
+}
+
+// Tracer starts spans and hands the sampled ones to its exporter once they
+// end.
+type Tracer struct {
+	exporter   Exporter
+	sampleRate float64
+	clock      clock.Clock
+
+	mx     *sync.Mutex
+	random *rand.Rand
+}
+
+// NewTracer creates a tracer sampling the given fraction of new traces.
+// Spans of an existing trace follow the sampling decision of their parent.
+func NewTracer(exporter Exporter, sampleRate float64) *Tracer {
+	return &Tracer{
+		exporter:   exporter,
+		sampleRate: sampleRate,
+		clock:      clock.Real,
+
+		mx:     &sync.Mutex{},
+		random: rand.New(rand.NewPCG(rand.Uint64(), rand.Uint64())),
+	}
+}
+
+// UseClock makes the tracer time spans with c.
+func (t *Tracer) UseClock(c clock.Clock) {
+	t.clock = c
+}
+
+// UseRandom replaces the source of trace and span IDs and of sampling.
+func (t *Tracer) UseRandom(r *rand.Rand) {
+	t.mx.Lock()
+	defer t.mx.Unlock()
+
+	t.random = r
+}
+
+func (t *Tracer) Now() time.Time {
+	return t.clock.Now()
+}
+
+// Start starts a span named name as a child of parent, or as the root of a
+// new trace if parent is not valid. The returned context carries the span.
+func (t *Tracer) Start(ctx context.Context, name string, parent SpanContext) (context.Context, *Span) {
+	return t.StartAt(ctx, name, parent, t.clock.Now())
+}
+
+// StartAt is Start for a span that started at start.
+func (t *Tracer) StartAt(ctx context.Context, name string, parent SpanContext, start time.Time) (context.Context, *Span) {
+	t.mx.Lock()
+	sc := SpanContext{TraceID: parent.TraceID, Sampled: parent.Sampled}
+	if !parent.IsValid() {
+		if !parent.TraceID.IsValid() {
+			t.fill(sc.TraceID[:])
+		}
+		sc.Sampled = t.random.Float64() < t.sampleRate
+	}
+	t.fill(sc.SpanID[:])
+	t.mx.Unlock()
+
+	span := &Span{
+		tracer:     t,
+		name:       name,
+		context:    sc,
+		start:      start,
+		attributes: make(map[string]string),
+
+		mx: &sync.Mutex{},
+	}
+	if parent.IsValid() {
+		span.parent = parent.SpanID
+	}
+
+	return ContextWithSpan(ctx, span), span
+}
+
+// fill writes random bytes into b. The caller must hold t.mx.
+func (t *Tracer) fill(b []byte) {
+	for i := range b {
+		b[i] = byte(t.random.Uint32())
+	}
+}
+
+// Span is a timed operation of a trace.
+type Span struct {
+	tracer  *Tracer
+	name    string
+	context SpanContext
+	parent  SpanID
+	start   time.Time
+
+	mx         *sync.Mutex
+	attributes map[string]string
+	err        error
+	ended      bool
+}
+
+func (s *Span) Context() SpanContext {
+	return s.context
+}
+
+func (s *Span) SetAttribute(key string, value string) {
+	s.mx.Lock()
+	defer s.mx.Unlock()
+
+	s.attributes[key] = value
+}
+
+// SetError marks the span as failed with err. A nil err changes nothing.
+func (s *Span) SetError(err error) {
+	if err == nil {
+		return
+	}
+
+	s.mx.Lock()
+	defer s.mx.Unlock()
+
+	s.err = err
+}
+
+// End records the end of the span and exports it if it is sampled. Only the
+// first call has an effect.
+func (s *Span) End() {
+	s.mx.Lock()
+	if s.ended {
+		s.mx.Unlock()
+		return
+	}
+	s.ended = true
+	data := s.data(s.tracer.clock.Now())
+	s.mx.Unlock()
+
+	if !s.context.Sampled {
+		return
+	}
+
+	s.tracer.export(data)
+}
+
+type spanKey struct{}
+
+// ContextWithSpan returns a copy of ctx carrying span.
+func ContextWithSpan(ctx context.Context, span *Span) context.Context {
+	return context.WithValue(ctx, spanKey{}, span)
+}
+
+// SpanFromContext returns the span carried by ctx.
+func SpanFromContext(ctx context.Context) (*Span, bool) {
+	span, ok := ctx.Value(spanKey{}).(*Span)
+
+	return span, ok
+}
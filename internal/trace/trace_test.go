@@ -0,0 +1,129 @@
+package trace
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestTraceparent(t *testing.T) {
+	tests := []struct {
+		label         string
+		header        string
+		expectedError error
+		sampled       bool
+	}{
+		{
+			label:   "sampled",
+			header:  "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01",
+			sampled: true,
+		},
+		{
+			label:  "not sampled",
+			header: "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-00",
+		},
+		{
+			label:         "empty",
+			header:        "",
+			expectedError: ErrInvalidTraceparent,
+		},
+		{
+			label:         "zero trace id",
+			header:        "00-00000000000000000000000000000000-00f067aa0ba902b7-01",
+			expectedError: ErrInvalidTraceparent,
+		},
+		{
+			label:         "unknown version",
+			header:        "ff-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01",
+			expectedError: ErrInvalidTraceparent,
+		},
+	}
+
+	for _, tt := range tests {
+		tf := func(t *testing.T) {
+			sc, err := ParseTraceparent(tt.header)
+			require.ErrorIs(t, err, tt.expectedError)
+			if tt.expectedError != nil {
+				return
+			}
+
+			require.Equal(t, tt.sampled, sc.Sampled)
+			require.Equal(t, tt.header, sc.Traceparent())
+		}
+
+		t.Run(tt.label, tf)
+	}
+}
+
+func TestTraceIDFrom(t *testing.T) {
+	id := "4bf92f3577b34da6a3ce929d0e0e4736"
+	require.Equal(t, id, TraceIDFrom(id).String())
+
+	require.Equal(t, TraceIDFrom("order-1"), TraceIDFrom("order-1"))
+	require.NotEqual(t, TraceIDFrom("order-1"), TraceIDFrom("order-2"))
+	require.True(t, TraceIDFrom("order-1").IsValid())
+}
+
+func TestSampling(t *testing.T) {
+	exporter := NewMemoryExporter()
+	tracer := NewTracer(exporter, 0)
+
+	ctx, root := tracer.Start(context.Background(), "root", SpanContext{})
+	require.False(t, root.Context().Sampled)
+
+	span, ok := SpanFromContext(ctx)
+	require.True(t, ok)
+	_, child := tracer.Start(ctx, "child", span.Context())
+	require.Equal(t, root.Context().TraceID, child.Context().TraceID)
+
+	child.End()
+	root.End()
+	require.Empty(t, exporter.Spans())
+
+	_, sampled := tracer.Start(context.Background(), "sampled", SpanContext{TraceID: root.Context().TraceID, SpanID: root.Context().SpanID, Sampled: true})
+	sampled.SetError(errors.New("failed"))
+	sampled.End()
+	sampled.End()
+
+	spans := exporter.Spans()
+	require.Len(t, spans, 1)
+	require.Equal(t, root.Context().SpanID.String(), spans[0].ParentSpanID)
+	require.Equal(t, StatusError, spans[0].Status.Code)
+}
+
+func TestFileExporter(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "spans.jsonl")
+	exporter, err := NewFileExporter(path)
+	require.NoError(t, err)
+
+	tracer := NewTracer(exporter, 1)
+	for _, name := range []string{"first", "second"} {
+		_, span := tracer.Start(context.Background(), name, SpanContext{})
+		span.SetAttribute("key", "value")
+		span.End()
+	}
+	require.NoError(t, exporter.Close())
+
+	file, err := os.Open(path)
+	require.NoError(t, err)
+	defer file.Close()
+
+	names := make([]string, 0)
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		var span SpanData
+		require.NoError(t, json.Unmarshal(scanner.Bytes(), &span))
+
+		value, ok := span.Attribute("key")
+		require.True(t, ok)
+		require.Equal(t, "value", value)
+		names = append(names, span.Name)
+	}
+	require.Equal(t, []string{"first", "second"}, names)
+}
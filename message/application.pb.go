@@ -1,6 +1,6 @@
 // Code generated by protoc-gen-go. DO NOT EDIT.
 // versions:
-// 	protoc-gen-go v1.26.0
+// 	protoc-gen-go v1.34.2
 // 	protoc        v4.23.3
 // source: application.proto
 
@@ -12,6 +12,7 @@ import (
 
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	durationpb "google.golang.org/protobuf/types/known/durationpb"
 	structpb "google.golang.org/protobuf/types/known/structpb"
 	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
 )
@@ -34,6 +35,8 @@ type BuildRequest struct {
 	Duration  string                 `protobuf:"bytes,4,opt,name=Duration,proto3" json:"Duration"`
 	Context   *structpb.Struct       `protobuf:"bytes,5,opt,name=Context,proto3" json:"Context"`
 	Status    string                 `protobuf:"bytes,6,opt,name=status,proto3" json:"status"`
+	ID        string                 `protobuf:"bytes,7,opt,name=ID,proto3" json:"ID"`
+	Cost      map[string]uint64      `protobuf:"bytes,8,rep,name=Cost,proto3" json:"Cost" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"varint,2,opt,name=value,proto3"`
 }
 
 func (x *BuildRequest) Reset() {
@@ -110,33 +113,1019 @@ func (x *BuildRequest) GetStatus() string {
 	return ""
 }
 
+func (x *BuildRequest) GetID() string {
+	if x != nil {
+		return x.ID
+	}
+	return ""
+}
+
+func (x *BuildRequest) GetCost() map[string]uint64 {
+	if x != nil {
+		return x.Cost
+	}
+	return nil
+}
+
 type BuildResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	TraceID   string                 `protobuf:"bytes,1,opt,name=TraceID,proto3" json:"TraceID"`
-	Timestamp *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=Timestamp,proto3" json:"Timestamp"`
-	Response  string                 `protobuf:"bytes,3,opt,name=Response,proto3" json:"Response"`
+	TraceID   string                 `protobuf:"bytes,1,opt,name=TraceID,proto3" json:"TraceID"`
+	Timestamp *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=Timestamp,proto3" json:"Timestamp"`
+	Response  string                 `protobuf:"bytes,3,opt,name=Response,proto3" json:"Response"`
+}
+
+func (x *BuildResponse) Reset() {
+	*x = BuildResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_application_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *BuildResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BuildResponse) ProtoMessage() {}
+
+func (x *BuildResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_application_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BuildResponse.ProtoReflect.Descriptor instead.
+func (*BuildResponse) Descriptor() ([]byte, []int) {
+	return file_application_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *BuildResponse) GetTraceID() string {
+	if x != nil {
+		return x.TraceID
+	}
+	return ""
+}
+
+func (x *BuildResponse) GetTimestamp() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Timestamp
+	}
+	return nil
+}
+
+func (x *BuildResponse) GetResponse() string {
+	if x != nil {
+		return x.Response
+	}
+	return ""
+}
+
+type SpeedUpRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	TraceID   string                 `protobuf:"bytes,1,opt,name=TraceID,proto3" json:"TraceID"`
+	Timestamp *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=Timestamp,proto3" json:"Timestamp"`
+	BuildID   string                 `protobuf:"bytes,3,opt,name=BuildID,proto3" json:"BuildID"`
+	Item      string                 `protobuf:"bytes,4,opt,name=Item,proto3" json:"Item"`
+	Duration  string                 `protobuf:"bytes,5,opt,name=Duration,proto3" json:"Duration"`
+	Percent   uint32                 `protobuf:"varint,6,opt,name=Percent,proto3" json:"Percent"`
+}
+
+func (x *SpeedUpRequest) Reset() {
+	*x = SpeedUpRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_application_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SpeedUpRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SpeedUpRequest) ProtoMessage() {}
+
+func (x *SpeedUpRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_application_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SpeedUpRequest.ProtoReflect.Descriptor instead.
+func (*SpeedUpRequest) Descriptor() ([]byte, []int) {
+	return file_application_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *SpeedUpRequest) GetTraceID() string {
+	if x != nil {
+		return x.TraceID
+	}
+	return ""
+}
+
+func (x *SpeedUpRequest) GetTimestamp() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Timestamp
+	}
+	return nil
+}
+
+func (x *SpeedUpRequest) GetBuildID() string {
+	if x != nil {
+		return x.BuildID
+	}
+	return ""
+}
+
+func (x *SpeedUpRequest) GetItem() string {
+	if x != nil {
+		return x.Item
+	}
+	return ""
+}
+
+func (x *SpeedUpRequest) GetDuration() string {
+	if x != nil {
+		return x.Duration
+	}
+	return ""
+}
+
+func (x *SpeedUpRequest) GetPercent() uint32 {
+	if x != nil {
+		return x.Percent
+	}
+	return 0
+}
+
+type Modifier struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ID        string                 `protobuf:"bytes,1,opt,name=ID,proto3" json:"ID"`
+	Name      string                 `protobuf:"bytes,2,opt,name=Name,proto3" json:"Name"`
+	Scope     string                 `protobuf:"bytes,3,opt,name=Scope,proto3" json:"Scope"`
+	Subject   string                 `protobuf:"bytes,4,opt,name=Subject,proto3" json:"Subject"`
+	Target    string                 `protobuf:"bytes,5,opt,name=Target,proto3" json:"Target"`
+	Resource  string                 `protobuf:"bytes,6,opt,name=Resource,proto3" json:"Resource"`
+	Operation string                 `protobuf:"bytes,7,opt,name=Operation,proto3" json:"Operation"`
+	Value     float64                `protobuf:"fixed64,8,opt,name=Value,proto3" json:"Value"`
+	Start     *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=Start,proto3" json:"Start"`
+	End       *timestamppb.Timestamp `protobuf:"bytes,10,opt,name=End,proto3" json:"End"`
+}
+
+func (x *Modifier) Reset() {
+	*x = Modifier{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_application_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Modifier) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Modifier) ProtoMessage() {}
+
+func (x *Modifier) ProtoReflect() protoreflect.Message {
+	mi := &file_application_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Modifier.ProtoReflect.Descriptor instead.
+func (*Modifier) Descriptor() ([]byte, []int) {
+	return file_application_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *Modifier) GetID() string {
+	if x != nil {
+		return x.ID
+	}
+	return ""
+}
+
+func (x *Modifier) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Modifier) GetScope() string {
+	if x != nil {
+		return x.Scope
+	}
+	return ""
+}
+
+func (x *Modifier) GetSubject() string {
+	if x != nil {
+		return x.Subject
+	}
+	return ""
+}
+
+func (x *Modifier) GetTarget() string {
+	if x != nil {
+		return x.Target
+	}
+	return ""
+}
+
+func (x *Modifier) GetResource() string {
+	if x != nil {
+		return x.Resource
+	}
+	return ""
+}
+
+func (x *Modifier) GetOperation() string {
+	if x != nil {
+		return x.Operation
+	}
+	return ""
+}
+
+func (x *Modifier) GetValue() float64 {
+	if x != nil {
+		return x.Value
+	}
+	return 0
+}
+
+func (x *Modifier) GetStart() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Start
+	}
+	return nil
+}
+
+func (x *Modifier) GetEnd() *timestamppb.Timestamp {
+	if x != nil {
+		return x.End
+	}
+	return nil
+}
+
+type ModifierEffect struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ModifierID string  `protobuf:"bytes,1,opt,name=ModifierID,proto3" json:"ModifierID"`
+	Target     string  `protobuf:"bytes,2,opt,name=Target,proto3" json:"Target"`
+	Resource   string  `protobuf:"bytes,3,opt,name=Resource,proto3" json:"Resource"`
+	Before     float64 `protobuf:"fixed64,4,opt,name=Before,proto3" json:"Before"`
+	After      float64 `protobuf:"fixed64,5,opt,name=After,proto3" json:"After"`
+}
+
+func (x *ModifierEffect) Reset() {
+	*x = ModifierEffect{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_application_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ModifierEffect) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ModifierEffect) ProtoMessage() {}
+
+func (x *ModifierEffect) ProtoReflect() protoreflect.Message {
+	mi := &file_application_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ModifierEffect.ProtoReflect.Descriptor instead.
+func (*ModifierEffect) Descriptor() ([]byte, []int) {
+	return file_application_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *ModifierEffect) GetModifierID() string {
+	if x != nil {
+		return x.ModifierID
+	}
+	return ""
+}
+
+func (x *ModifierEffect) GetTarget() string {
+	if x != nil {
+		return x.Target
+	}
+	return ""
+}
+
+func (x *ModifierEffect) GetResource() string {
+	if x != nil {
+		return x.Resource
+	}
+	return ""
+}
+
+func (x *ModifierEffect) GetBefore() float64 {
+	if x != nil {
+		return x.Before
+	}
+	return 0
+}
+
+func (x *ModifierEffect) GetAfter() float64 {
+	if x != nil {
+		return x.After
+	}
+	return 0
+}
+
+type ModifierQuery struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	TraceID   string                 `protobuf:"bytes,1,opt,name=TraceID,proto3" json:"TraceID"`
+	Timestamp *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=Timestamp,proto3" json:"Timestamp"`
+	Build     *BuildRequest          `protobuf:"bytes,3,opt,name=Build,proto3" json:"Build"`
+}
+
+func (x *ModifierQuery) Reset() {
+	*x = ModifierQuery{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_application_proto_msgTypes[5]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ModifierQuery) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ModifierQuery) ProtoMessage() {}
+
+func (x *ModifierQuery) ProtoReflect() protoreflect.Message {
+	mi := &file_application_proto_msgTypes[5]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ModifierQuery.ProtoReflect.Descriptor instead.
+func (*ModifierQuery) Descriptor() ([]byte, []int) {
+	return file_application_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *ModifierQuery) GetTraceID() string {
+	if x != nil {
+		return x.TraceID
+	}
+	return ""
+}
+
+func (x *ModifierQuery) GetTimestamp() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Timestamp
+	}
+	return nil
+}
+
+func (x *ModifierQuery) GetBuild() *BuildRequest {
+	if x != nil {
+		return x.Build
+	}
+	return nil
+}
+
+type ModifierResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	TraceID   string                 `protobuf:"bytes,1,opt,name=TraceID,proto3" json:"TraceID"`
+	Timestamp *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=Timestamp,proto3" json:"Timestamp"`
+	Active    []*Modifier            `protobuf:"bytes,3,rep,name=Active,proto3" json:"Active"`
+	Duration  string                 `protobuf:"bytes,4,opt,name=Duration,proto3" json:"Duration"`
+	Cost      map[string]uint64      `protobuf:"bytes,5,rep,name=Cost,proto3" json:"Cost" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"varint,2,opt,name=value,proto3"`
+	Effects   []*ModifierEffect      `protobuf:"bytes,6,rep,name=Effects,proto3" json:"Effects"`
+}
+
+func (x *ModifierResponse) Reset() {
+	*x = ModifierResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_application_proto_msgTypes[6]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ModifierResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ModifierResponse) ProtoMessage() {}
+
+func (x *ModifierResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_application_proto_msgTypes[6]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ModifierResponse.ProtoReflect.Descriptor instead.
+func (*ModifierResponse) Descriptor() ([]byte, []int) {
+	return file_application_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *ModifierResponse) GetTraceID() string {
+	if x != nil {
+		return x.TraceID
+	}
+	return ""
+}
+
+func (x *ModifierResponse) GetTimestamp() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Timestamp
+	}
+	return nil
+}
+
+func (x *ModifierResponse) GetActive() []*Modifier {
+	if x != nil {
+		return x.Active
+	}
+	return nil
+}
+
+func (x *ModifierResponse) GetDuration() string {
+	if x != nil {
+		return x.Duration
+	}
+	return ""
+}
+
+func (x *ModifierResponse) GetCost() map[string]uint64 {
+	if x != nil {
+		return x.Cost
+	}
+	return nil
+}
+
+func (x *ModifierResponse) GetEffects() []*ModifierEffect {
+	if x != nil {
+		return x.Effects
+	}
+	return nil
+}
+
+type CraftRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	TraceID   string                 `protobuf:"bytes,1,opt,name=TraceID,proto3" json:"TraceID"`
+	Timestamp *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=Timestamp,proto3" json:"Timestamp"`
+	ID        string                 `protobuf:"bytes,3,opt,name=ID,proto3" json:"ID"`
+	Recipe    string                 `protobuf:"bytes,4,opt,name=Recipe,proto3" json:"Recipe"`
+	Context   *structpb.Struct       `protobuf:"bytes,5,opt,name=Context,proto3" json:"Context"`
+}
+
+func (x *CraftRequest) Reset() {
+	*x = CraftRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_application_proto_msgTypes[7]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CraftRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CraftRequest) ProtoMessage() {}
+
+func (x *CraftRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_application_proto_msgTypes[7]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CraftRequest.ProtoReflect.Descriptor instead.
+func (*CraftRequest) Descriptor() ([]byte, []int) {
+	return file_application_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *CraftRequest) GetTraceID() string {
+	if x != nil {
+		return x.TraceID
+	}
+	return ""
+}
+
+func (x *CraftRequest) GetTimestamp() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Timestamp
+	}
+	return nil
+}
+
+func (x *CraftRequest) GetID() string {
+	if x != nil {
+		return x.ID
+	}
+	return ""
+}
+
+func (x *CraftRequest) GetRecipe() string {
+	if x != nil {
+		return x.Recipe
+	}
+	return ""
+}
+
+func (x *CraftRequest) GetContext() *structpb.Struct {
+	if x != nil {
+		return x.Context
+	}
+	return nil
+}
+
+type BuildingCompleted struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	BuildID     string                 `protobuf:"bytes,1,opt,name=BuildID,proto3" json:"BuildID"`
+	Name        string                 `protobuf:"bytes,2,opt,name=Name,proto3" json:"Name"`
+	Inventory   *Address               `protobuf:"bytes,3,opt,name=Inventory,proto3" json:"Inventory"`
+	CompletedAt *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=CompletedAt,proto3" json:"CompletedAt"`
+}
+
+func (x *BuildingCompleted) Reset() {
+	*x = BuildingCompleted{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_application_proto_msgTypes[8]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *BuildingCompleted) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BuildingCompleted) ProtoMessage() {}
+
+func (x *BuildingCompleted) ProtoReflect() protoreflect.Message {
+	mi := &file_application_proto_msgTypes[8]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BuildingCompleted.ProtoReflect.Descriptor instead.
+func (*BuildingCompleted) Descriptor() ([]byte, []int) {
+	return file_application_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *BuildingCompleted) GetBuildID() string {
+	if x != nil {
+		return x.BuildID
+	}
+	return ""
+}
+
+func (x *BuildingCompleted) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *BuildingCompleted) GetInventory() *Address {
+	if x != nil {
+		return x.Inventory
+	}
+	return nil
+}
+
+func (x *BuildingCompleted) GetCompletedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CompletedAt
+	}
+	return nil
+}
+
+type BuildTimerFired struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	BuildID string `protobuf:"bytes,1,opt,name=BuildID,proto3" json:"BuildID"`
+}
+
+func (x *BuildTimerFired) Reset() {
+	*x = BuildTimerFired{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_application_proto_msgTypes[9]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *BuildTimerFired) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BuildTimerFired) ProtoMessage() {}
+
+func (x *BuildTimerFired) ProtoReflect() protoreflect.Message {
+	mi := &file_application_proto_msgTypes[9]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BuildTimerFired.ProtoReflect.Descriptor instead.
+func (*BuildTimerFired) Descriptor() ([]byte, []int) {
+	return file_application_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *BuildTimerFired) GetBuildID() string {
+	if x != nil {
+		return x.BuildID
+	}
+	return ""
+}
+
+type CraftTimerFired struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	CraftID string `protobuf:"bytes,1,opt,name=CraftID,proto3" json:"CraftID"`
+}
+
+func (x *CraftTimerFired) Reset() {
+	*x = CraftTimerFired{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_application_proto_msgTypes[10]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CraftTimerFired) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CraftTimerFired) ProtoMessage() {}
+
+func (x *CraftTimerFired) ProtoReflect() protoreflect.Message {
+	mi := &file_application_proto_msgTypes[10]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CraftTimerFired.ProtoReflect.Descriptor instead.
+func (*CraftTimerFired) Descriptor() ([]byte, []int) {
+	return file_application_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *CraftTimerFired) GetCraftID() string {
+	if x != nil {
+		return x.CraftID
+	}
+	return ""
+}
+
+type InventorySnapshot struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Buildings  []*SavedItem  `protobuf:"bytes,1,rep,name=Buildings,proto3" json:"Buildings"`
+	Resources  []*SavedItem  `protobuf:"bytes,2,rep,name=Resources,proto3" json:"Resources"`
+	Current    *SavedBuild   `protobuf:"bytes,3,opt,name=Current,proto3" json:"Current"`
+	BuildQueue []*SavedBuild `protobuf:"bytes,4,rep,name=BuildQueue,proto3" json:"BuildQueue"`
+	Crafting   *SavedCraft   `protobuf:"bytes,5,opt,name=Crafting,proto3" json:"Crafting"`
+	CraftQueue []*SavedCraft `protobuf:"bytes,6,rep,name=CraftQueue,proto3" json:"CraftQueue"`
+}
+
+func (x *InventorySnapshot) Reset() {
+	*x = InventorySnapshot{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_application_proto_msgTypes[11]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *InventorySnapshot) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*InventorySnapshot) ProtoMessage() {}
+
+func (x *InventorySnapshot) ProtoReflect() protoreflect.Message {
+	mi := &file_application_proto_msgTypes[11]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use InventorySnapshot.ProtoReflect.Descriptor instead.
+func (*InventorySnapshot) Descriptor() ([]byte, []int) {
+	return file_application_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *InventorySnapshot) GetBuildings() []*SavedItem {
+	if x != nil {
+		return x.Buildings
+	}
+	return nil
+}
+
+func (x *InventorySnapshot) GetResources() []*SavedItem {
+	if x != nil {
+		return x.Resources
+	}
+	return nil
+}
+
+func (x *InventorySnapshot) GetCurrent() *SavedBuild {
+	if x != nil {
+		return x.Current
+	}
+	return nil
+}
+
+func (x *InventorySnapshot) GetBuildQueue() []*SavedBuild {
+	if x != nil {
+		return x.BuildQueue
+	}
+	return nil
+}
+
+func (x *InventorySnapshot) GetCrafting() *SavedCraft {
+	if x != nil {
+		return x.Crafting
+	}
+	return nil
+}
+
+func (x *InventorySnapshot) GetCraftQueue() []*SavedCraft {
+	if x != nil {
+		return x.CraftQueue
+	}
+	return nil
+}
+
+type SavedItem struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ID     string `protobuf:"bytes,1,opt,name=ID,proto3" json:"ID"`
+	Name   string `protobuf:"bytes,2,opt,name=Name,proto3" json:"Name"`
+	Amount uint64 `protobuf:"varint,3,opt,name=Amount,proto3" json:"Amount"`
+}
+
+func (x *SavedItem) Reset() {
+	*x = SavedItem{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_application_proto_msgTypes[12]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SavedItem) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SavedItem) ProtoMessage() {}
+
+func (x *SavedItem) ProtoReflect() protoreflect.Message {
+	mi := &file_application_proto_msgTypes[12]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SavedItem.ProtoReflect.Descriptor instead.
+func (*SavedItem) Descriptor() ([]byte, []int) {
+	return file_application_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *SavedItem) GetID() string {
+	if x != nil {
+		return x.ID
+	}
+	return ""
+}
+
+func (x *SavedItem) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *SavedItem) GetAmount() uint64 {
+	if x != nil {
+		return x.Amount
+	}
+	return 0
+}
+
+type SavedBuild struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Request *BuildRequest          `protobuf:"bytes,1,opt,name=Request,proto3" json:"Request"`
+	FireAt  *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=FireAt,proto3" json:"FireAt"`
+	WakeID  string                 `protobuf:"bytes,3,opt,name=WakeID,proto3" json:"WakeID"`
+	SpedUp  *durationpb.Duration   `protobuf:"bytes,4,opt,name=SpedUp,proto3" json:"SpedUp"`
+	Cost    map[string]uint64      `protobuf:"bytes,5,rep,name=Cost,proto3" json:"Cost" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"varint,2,opt,name=value,proto3"`
+}
+
+func (x *SavedBuild) Reset() {
+	*x = SavedBuild{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_application_proto_msgTypes[13]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SavedBuild) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SavedBuild) ProtoMessage() {}
+
+func (x *SavedBuild) ProtoReflect() protoreflect.Message {
+	mi := &file_application_proto_msgTypes[13]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SavedBuild.ProtoReflect.Descriptor instead.
+func (*SavedBuild) Descriptor() ([]byte, []int) {
+	return file_application_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *SavedBuild) GetRequest() *BuildRequest {
+	if x != nil {
+		return x.Request
+	}
+	return nil
+}
+
+func (x *SavedBuild) GetFireAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.FireAt
+	}
+	return nil
+}
+
+func (x *SavedBuild) GetWakeID() string {
+	if x != nil {
+		return x.WakeID
+	}
+	return ""
+}
+
+func (x *SavedBuild) GetSpedUp() *durationpb.Duration {
+	if x != nil {
+		return x.SpedUp
+	}
+	return nil
+}
+
+func (x *SavedBuild) GetCost() map[string]uint64 {
+	if x != nil {
+		return x.Cost
+	}
+	return nil
+}
+
+type SavedCraft struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Request *CraftRequest          `protobuf:"bytes,1,opt,name=Request,proto3" json:"Request"`
+	Recipe  *SavedRecipe           `protobuf:"bytes,2,opt,name=Recipe,proto3" json:"Recipe"`
+	FireAt  *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=FireAt,proto3" json:"FireAt"`
+	WakeID  string                 `protobuf:"bytes,4,opt,name=WakeID,proto3" json:"WakeID"`
 }
 
-func (x *BuildResponse) Reset() {
-	*x = BuildResponse{}
+func (x *SavedCraft) Reset() {
+	*x = SavedCraft{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_application_proto_msgTypes[1]
+		mi := &file_application_proto_msgTypes[14]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *BuildResponse) String() string {
+func (x *SavedCraft) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*BuildResponse) ProtoMessage() {}
+func (*SavedCraft) ProtoMessage() {}
 
-func (x *BuildResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_application_proto_msgTypes[1]
+func (x *SavedCraft) ProtoReflect() protoreflect.Message {
+	mi := &file_application_proto_msgTypes[14]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -147,67 +1136,358 @@ func (x *BuildResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use BuildResponse.ProtoReflect.Descriptor instead.
-func (*BuildResponse) Descriptor() ([]byte, []int) {
-	return file_application_proto_rawDescGZIP(), []int{1}
+// Deprecated: Use SavedCraft.ProtoReflect.Descriptor instead.
+func (*SavedCraft) Descriptor() ([]byte, []int) {
+	return file_application_proto_rawDescGZIP(), []int{14}
 }
 
-func (x *BuildResponse) GetTraceID() string {
+func (x *SavedCraft) GetRequest() *CraftRequest {
 	if x != nil {
-		return x.TraceID
+		return x.Request
 	}
-	return ""
+	return nil
 }
 
-func (x *BuildResponse) GetTimestamp() *timestamppb.Timestamp {
+func (x *SavedCraft) GetRecipe() *SavedRecipe {
 	if x != nil {
-		return x.Timestamp
+		return x.Recipe
 	}
 	return nil
 }
 
-func (x *BuildResponse) GetResponse() string {
+func (x *SavedCraft) GetFireAt() *timestamppb.Timestamp {
 	if x != nil {
-		return x.Response
+		return x.FireAt
+	}
+	return nil
+}
+
+func (x *SavedCraft) GetWakeID() string {
+	if x != nil {
+		return x.WakeID
+	}
+	return ""
+}
+
+type SavedRecipe struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Name     string               `protobuf:"bytes,1,opt,name=Name,proto3" json:"Name"`
+	Building string               `protobuf:"bytes,2,opt,name=Building,proto3" json:"Building"`
+	Inputs   map[string]uint64    `protobuf:"bytes,3,rep,name=Inputs,proto3" json:"Inputs" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"varint,2,opt,name=value,proto3"`
+	Outputs  map[string]uint64    `protobuf:"bytes,4,rep,name=Outputs,proto3" json:"Outputs" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"varint,2,opt,name=value,proto3"`
+	Duration *durationpb.Duration `protobuf:"bytes,5,opt,name=Duration,proto3" json:"Duration"`
+}
+
+func (x *SavedRecipe) Reset() {
+	*x = SavedRecipe{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_application_proto_msgTypes[15]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SavedRecipe) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SavedRecipe) ProtoMessage() {}
+
+func (x *SavedRecipe) ProtoReflect() protoreflect.Message {
+	mi := &file_application_proto_msgTypes[15]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SavedRecipe.ProtoReflect.Descriptor instead.
+func (*SavedRecipe) Descriptor() ([]byte, []int) {
+	return file_application_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *SavedRecipe) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *SavedRecipe) GetBuilding() string {
+	if x != nil {
+		return x.Building
 	}
 	return ""
 }
 
+func (x *SavedRecipe) GetInputs() map[string]uint64 {
+	if x != nil {
+		return x.Inputs
+	}
+	return nil
+}
+
+func (x *SavedRecipe) GetOutputs() map[string]uint64 {
+	if x != nil {
+		return x.Outputs
+	}
+	return nil
+}
+
+func (x *SavedRecipe) GetDuration() *durationpb.Duration {
+	if x != nil {
+		return x.Duration
+	}
+	return nil
+}
+
 var File_application_proto protoreflect.FileDescriptor
 
 var file_application_proto_rawDesc = []byte{
 	0x0a, 0x11, 0x61, 0x70, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x70, 0x72,
-	0x6f, 0x74, 0x6f, 0x12, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x1a, 0x1c, 0x67, 0x6f,
-	0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x73, 0x74,
-	0x72, 0x75, 0x63, 0x74, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x1f, 0x67, 0x6f, 0x6f, 0x67,
-	0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x74, 0x69, 0x6d, 0x65,
-	0x73, 0x74, 0x61, 0x6d, 0x70, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0xdd, 0x01, 0x0a, 0x0c,
-	0x42, 0x75, 0x69, 0x6c, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x18, 0x0a, 0x07,
-	0x54, 0x72, 0x61, 0x63, 0x65, 0x49, 0x44, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x54,
-	0x72, 0x61, 0x63, 0x65, 0x49, 0x44, 0x12, 0x38, 0x0a, 0x09, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74,
-	0x61, 0x6d, 0x70, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67,
+	0x6f, 0x74, 0x6f, 0x12, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x1a, 0x0b, 0x61, 0x63,
+	0x74, 0x6f, 0x72, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x1e, 0x67, 0x6f, 0x6f, 0x67, 0x6c,
+	0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x64, 0x75, 0x72, 0x61, 0x74,
+	0x69, 0x6f, 0x6e, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x1c, 0x67, 0x6f, 0x6f, 0x67, 0x6c,
+	0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x73, 0x74, 0x72, 0x75, 0x63,
+	0x74, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x1f, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61,
+	0x6d, 0x70, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x0d, 0x6f, 0x70, 0x74, 0x69, 0x6f, 0x6e,
+	0x73, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0xdb, 0x02, 0x0a, 0x0c, 0x42, 0x75, 0x69, 0x6c,
+	0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x18, 0x0a, 0x07, 0x54, 0x72, 0x61, 0x63,
+	0x65, 0x49, 0x44, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x54, 0x72, 0x61, 0x63, 0x65,
+	0x49, 0x44, 0x12, 0x38, 0x0a, 0x09, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d,
+	0x70, 0x52, 0x09, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x12, 0x12, 0x0a, 0x04,
+	0x4e, 0x61, 0x6d, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x4e, 0x61, 0x6d, 0x65,
+	0x12, 0x1a, 0x0a, 0x08, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x04, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x08, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x31, 0x0a, 0x07,
+	0x43, 0x6f, 0x6e, 0x74, 0x65, 0x78, 0x74, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x17, 0x2e,
+	0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e,
+	0x53, 0x74, 0x72, 0x75, 0x63, 0x74, 0x52, 0x07, 0x43, 0x6f, 0x6e, 0x74, 0x65, 0x78, 0x74, 0x12,
+	0x16, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x0e, 0x0a, 0x02, 0x49, 0x44, 0x18, 0x07, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x02, 0x49, 0x44, 0x12, 0x33, 0x0a, 0x04, 0x43, 0x6f, 0x73, 0x74, 0x18,
+	0x08, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1f, 0x2e, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x2e,
+	0x42, 0x75, 0x69, 0x6c, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x2e, 0x43, 0x6f, 0x73,
+	0x74, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x04, 0x43, 0x6f, 0x73, 0x74, 0x1a, 0x37, 0x0a, 0x09,
+	0x43, 0x6f, 0x73, 0x74, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76,
+	0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75,
+	0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0x7f, 0x0a, 0x0d, 0x42, 0x75, 0x69, 0x6c, 0x64, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x54, 0x72, 0x61, 0x63, 0x65, 0x49,
+	0x44, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x54, 0x72, 0x61, 0x63, 0x65, 0x49, 0x44,
+	0x12, 0x38, 0x0a, 0x09, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52,
+	0x09, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x12, 0x1a, 0x0a, 0x08, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0xc8, 0x01, 0x0a, 0x0e, 0x53, 0x70, 0x65, 0x65, 0x64,
+	0x55, 0x70, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x18, 0x0a, 0x07, 0x54, 0x72, 0x61,
+	0x63, 0x65, 0x49, 0x44, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x54, 0x72, 0x61, 0x63,
+	0x65, 0x49, 0x44, 0x12, 0x38, 0x0a, 0x09, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61,
+	0x6d, 0x70, 0x52, 0x09, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x12, 0x18, 0x0a,
+	0x07, 0x42, 0x75, 0x69, 0x6c, 0x64, 0x49, 0x44, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07,
+	0x42, 0x75, 0x69, 0x6c, 0x64, 0x49, 0x44, 0x12, 0x12, 0x0a, 0x04, 0x49, 0x74, 0x65, 0x6d, 0x18,
+	0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x49, 0x74, 0x65, 0x6d, 0x12, 0x1a, 0x0a, 0x08, 0x44,
+	0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x44,
+	0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x18, 0x0a, 0x07, 0x50, 0x65, 0x72, 0x63, 0x65,
+	0x6e, 0x74, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x07, 0x50, 0x65, 0x72, 0x63, 0x65, 0x6e,
+	0x74, 0x22, 0xa6, 0x02, 0x0a, 0x08, 0x4d, 0x6f, 0x64, 0x69, 0x66, 0x69, 0x65, 0x72, 0x12, 0x0e,
+	0x0a, 0x02, 0x49, 0x44, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x49, 0x44, 0x12, 0x12,
+	0x0a, 0x04, 0x4e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x4e, 0x61,
+	0x6d, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x53, 0x63, 0x6f, 0x70, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x05, 0x53, 0x63, 0x6f, 0x70, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x53, 0x75, 0x62, 0x6a,
+	0x65, 0x63, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x53, 0x75, 0x62, 0x6a, 0x65,
+	0x63, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x54, 0x61, 0x72, 0x67, 0x65, 0x74, 0x18, 0x05, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x06, 0x54, 0x61, 0x72, 0x67, 0x65, 0x74, 0x12, 0x1a, 0x0a, 0x08, 0x52, 0x65,
+	0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x52, 0x65,
+	0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x12, 0x1c, 0x0a, 0x09, 0x4f, 0x70, 0x65, 0x72, 0x61, 0x74,
+	0x69, 0x6f, 0x6e, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x4f, 0x70, 0x65, 0x72, 0x61,
+	0x74, 0x69, 0x6f, 0x6e, 0x12, 0x14, 0x0a, 0x05, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x08, 0x20,
+	0x01, 0x28, 0x01, 0x52, 0x05, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x12, 0x30, 0x0a, 0x05, 0x53, 0x74,
+	0x61, 0x72, 0x74, 0x18, 0x09, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67,
+	0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65,
+	0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x05, 0x53, 0x74, 0x61, 0x72, 0x74, 0x12, 0x2c, 0x0a, 0x03,
+	0x45, 0x6e, 0x64, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67,
 	0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65,
-	0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x09, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70,
-	0x12, 0x12, 0x0a, 0x04, 0x4e, 0x61, 0x6d, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04,
-	0x4e, 0x61, 0x6d, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e,
-	0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e,
-	0x12, 0x31, 0x0a, 0x07, 0x43, 0x6f, 0x6e, 0x74, 0x65, 0x78, 0x74, 0x18, 0x05, 0x20, 0x01, 0x28,
-	0x0b, 0x32, 0x17, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
-	0x62, 0x75, 0x66, 0x2e, 0x53, 0x74, 0x72, 0x75, 0x63, 0x74, 0x52, 0x07, 0x43, 0x6f, 0x6e, 0x74,
-	0x65, 0x78, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x06, 0x20,
-	0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x22, 0x7f, 0x0a, 0x0d, 0x42,
-	0x75, 0x69, 0x6c, 0x64, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x18, 0x0a, 0x07,
-	0x54, 0x72, 0x61, 0x63, 0x65, 0x49, 0x44, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x54,
-	0x72, 0x61, 0x63, 0x65, 0x49, 0x44, 0x12, 0x38, 0x0a, 0x09, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74,
-	0x61, 0x6d, 0x70, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67,
+	0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x03, 0x45, 0x6e, 0x64, 0x22, 0x92, 0x01, 0x0a, 0x0e, 0x4d,
+	0x6f, 0x64, 0x69, 0x66, 0x69, 0x65, 0x72, 0x45, 0x66, 0x66, 0x65, 0x63, 0x74, 0x12, 0x1e, 0x0a,
+	0x0a, 0x4d, 0x6f, 0x64, 0x69, 0x66, 0x69, 0x65, 0x72, 0x49, 0x44, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x0a, 0x4d, 0x6f, 0x64, 0x69, 0x66, 0x69, 0x65, 0x72, 0x49, 0x44, 0x12, 0x16, 0x0a,
+	0x06, 0x54, 0x61, 0x72, 0x67, 0x65, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x54,
+	0x61, 0x72, 0x67, 0x65, 0x74, 0x12, 0x1a, 0x0a, 0x08, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63,
+	0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63,
+	0x65, 0x12, 0x16, 0x0a, 0x06, 0x42, 0x65, 0x66, 0x6f, 0x72, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28,
+	0x01, 0x52, 0x06, 0x42, 0x65, 0x66, 0x6f, 0x72, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x41, 0x66, 0x74,
+	0x65, 0x72, 0x18, 0x05, 0x20, 0x01, 0x28, 0x01, 0x52, 0x05, 0x41, 0x66, 0x74, 0x65, 0x72, 0x22,
+	0x90, 0x01, 0x0a, 0x0d, 0x4d, 0x6f, 0x64, 0x69, 0x66, 0x69, 0x65, 0x72, 0x51, 0x75, 0x65, 0x72,
+	0x79, 0x12, 0x18, 0x0a, 0x07, 0x54, 0x72, 0x61, 0x63, 0x65, 0x49, 0x44, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x07, 0x54, 0x72, 0x61, 0x63, 0x65, 0x49, 0x44, 0x12, 0x38, 0x0a, 0x09, 0x54,
+	0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a,
+	0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66,
+	0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x09, 0x54, 0x69, 0x6d, 0x65,
+	0x73, 0x74, 0x61, 0x6d, 0x70, 0x12, 0x2b, 0x0a, 0x05, 0x42, 0x75, 0x69, 0x6c, 0x64, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x2e, 0x42,
+	0x75, 0x69, 0x6c, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x52, 0x05, 0x42, 0x75, 0x69,
+	0x6c, 0x64, 0x22, 0xd2, 0x02, 0x0a, 0x10, 0x4d, 0x6f, 0x64, 0x69, 0x66, 0x69, 0x65, 0x72, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x54, 0x72, 0x61, 0x63, 0x65,
+	0x49, 0x44, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x54, 0x72, 0x61, 0x63, 0x65, 0x49,
+	0x44, 0x12, 0x38, 0x0a, 0x09, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70,
+	0x52, 0x09, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x12, 0x29, 0x0a, 0x06, 0x41,
+	0x63, 0x74, 0x69, 0x76, 0x65, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x6d, 0x65,
+	0x73, 0x73, 0x61, 0x67, 0x65, 0x2e, 0x4d, 0x6f, 0x64, 0x69, 0x66, 0x69, 0x65, 0x72, 0x52, 0x06,
+	0x41, 0x63, 0x74, 0x69, 0x76, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69,
+	0x6f, 0x6e, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69,
+	0x6f, 0x6e, 0x12, 0x37, 0x0a, 0x04, 0x43, 0x6f, 0x73, 0x74, 0x18, 0x05, 0x20, 0x03, 0x28, 0x0b,
+	0x32, 0x23, 0x2e, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x2e, 0x4d, 0x6f, 0x64, 0x69, 0x66,
+	0x69, 0x65, 0x72, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x2e, 0x43, 0x6f, 0x73, 0x74,
+	0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x04, 0x43, 0x6f, 0x73, 0x74, 0x12, 0x31, 0x0a, 0x07, 0x45,
+	0x66, 0x66, 0x65, 0x63, 0x74, 0x73, 0x18, 0x06, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x6d,
+	0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x2e, 0x4d, 0x6f, 0x64, 0x69, 0x66, 0x69, 0x65, 0x72, 0x45,
+	0x66, 0x66, 0x65, 0x63, 0x74, 0x52, 0x07, 0x45, 0x66, 0x66, 0x65, 0x63, 0x74, 0x73, 0x1a, 0x37,
+	0x0a, 0x09, 0x43, 0x6f, 0x73, 0x74, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b,
+	0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a,
+	0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x05, 0x76, 0x61,
+	0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0xbd, 0x01, 0x0a, 0x0c, 0x43, 0x72, 0x61, 0x66,
+	0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x18, 0x0a, 0x07, 0x54, 0x72, 0x61, 0x63,
+	0x65, 0x49, 0x44, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x54, 0x72, 0x61, 0x63, 0x65,
+	0x49, 0x44, 0x12, 0x38, 0x0a, 0x09, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d,
+	0x70, 0x52, 0x09, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x12, 0x0e, 0x0a, 0x02,
+	0x49, 0x44, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x49, 0x44, 0x12, 0x16, 0x0a, 0x06,
+	0x52, 0x65, 0x63, 0x69, 0x70, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x52, 0x65,
+	0x63, 0x69, 0x70, 0x65, 0x12, 0x31, 0x0a, 0x07, 0x43, 0x6f, 0x6e, 0x74, 0x65, 0x78, 0x74, 0x18,
+	0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x53, 0x74, 0x72, 0x75, 0x63, 0x74, 0x52, 0x07,
+	0x43, 0x6f, 0x6e, 0x74, 0x65, 0x78, 0x74, 0x22, 0xaf, 0x01, 0x0a, 0x11, 0x42, 0x75, 0x69, 0x6c,
+	0x64, 0x69, 0x6e, 0x67, 0x43, 0x6f, 0x6d, 0x70, 0x6c, 0x65, 0x74, 0x65, 0x64, 0x12, 0x18, 0x0a,
+	0x07, 0x42, 0x75, 0x69, 0x6c, 0x64, 0x49, 0x44, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07,
+	0x42, 0x75, 0x69, 0x6c, 0x64, 0x49, 0x44, 0x12, 0x12, 0x0a, 0x04, 0x4e, 0x61, 0x6d, 0x65, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x2e, 0x0a, 0x09, 0x49,
+	0x6e, 0x76, 0x65, 0x6e, 0x74, 0x6f, 0x72, 0x79, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x10,
+	0x2e, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x2e, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73,
+	0x52, 0x09, 0x49, 0x6e, 0x76, 0x65, 0x6e, 0x74, 0x6f, 0x72, 0x79, 0x12, 0x3c, 0x0a, 0x0b, 0x43,
+	0x6f, 0x6d, 0x70, 0x6c, 0x65, 0x74, 0x65, 0x64, 0x41, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62,
+	0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x0b, 0x43, 0x6f,
+	0x6d, 0x70, 0x6c, 0x65, 0x74, 0x65, 0x64, 0x41, 0x74, 0x22, 0x2b, 0x0a, 0x0f, 0x42, 0x75, 0x69,
+	0x6c, 0x64, 0x54, 0x69, 0x6d, 0x65, 0x72, 0x46, 0x69, 0x72, 0x65, 0x64, 0x12, 0x18, 0x0a, 0x07,
+	0x42, 0x75, 0x69, 0x6c, 0x64, 0x49, 0x44, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x42,
+	0x75, 0x69, 0x6c, 0x64, 0x49, 0x44, 0x22, 0x2b, 0x0a, 0x0f, 0x43, 0x72, 0x61, 0x66, 0x74, 0x54,
+	0x69, 0x6d, 0x65, 0x72, 0x46, 0x69, 0x72, 0x65, 0x64, 0x12, 0x18, 0x0a, 0x07, 0x43, 0x72, 0x61,
+	0x66, 0x74, 0x49, 0x44, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x43, 0x72, 0x61, 0x66,
+	0x74, 0x49, 0x44, 0x22, 0xc1, 0x02, 0x0a, 0x11, 0x49, 0x6e, 0x76, 0x65, 0x6e, 0x74, 0x6f, 0x72,
+	0x79, 0x53, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x12, 0x30, 0x0a, 0x09, 0x42, 0x75, 0x69,
+	0x6c, 0x64, 0x69, 0x6e, 0x67, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x6d,
+	0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x2e, 0x53, 0x61, 0x76, 0x65, 0x64, 0x49, 0x74, 0x65, 0x6d,
+	0x52, 0x09, 0x42, 0x75, 0x69, 0x6c, 0x64, 0x69, 0x6e, 0x67, 0x73, 0x12, 0x30, 0x0a, 0x09, 0x52,
+	0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x12,
+	0x2e, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x2e, 0x53, 0x61, 0x76, 0x65, 0x64, 0x49, 0x74,
+	0x65, 0x6d, 0x52, 0x09, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x73, 0x12, 0x2d, 0x0a,
+	0x07, 0x43, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x13,
+	0x2e, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x2e, 0x53, 0x61, 0x76, 0x65, 0x64, 0x42, 0x75,
+	0x69, 0x6c, 0x64, 0x52, 0x07, 0x43, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x74, 0x12, 0x33, 0x0a, 0x0a,
+	0x42, 0x75, 0x69, 0x6c, 0x64, 0x51, 0x75, 0x65, 0x75, 0x65, 0x18, 0x04, 0x20, 0x03, 0x28, 0x0b,
+	0x32, 0x13, 0x2e, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x2e, 0x53, 0x61, 0x76, 0x65, 0x64,
+	0x42, 0x75, 0x69, 0x6c, 0x64, 0x52, 0x0a, 0x42, 0x75, 0x69, 0x6c, 0x64, 0x51, 0x75, 0x65, 0x75,
+	0x65, 0x12, 0x2f, 0x0a, 0x08, 0x43, 0x72, 0x61, 0x66, 0x74, 0x69, 0x6e, 0x67, 0x18, 0x05, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x2e, 0x53, 0x61,
+	0x76, 0x65, 0x64, 0x43, 0x72, 0x61, 0x66, 0x74, 0x52, 0x08, 0x43, 0x72, 0x61, 0x66, 0x74, 0x69,
+	0x6e, 0x67, 0x12, 0x33, 0x0a, 0x0a, 0x43, 0x72, 0x61, 0x66, 0x74, 0x51, 0x75, 0x65, 0x75, 0x65,
+	0x18, 0x06, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65,
+	0x2e, 0x53, 0x61, 0x76, 0x65, 0x64, 0x43, 0x72, 0x61, 0x66, 0x74, 0x52, 0x0a, 0x43, 0x72, 0x61,
+	0x66, 0x74, 0x51, 0x75, 0x65, 0x75, 0x65, 0x22, 0x47, 0x0a, 0x09, 0x53, 0x61, 0x76, 0x65, 0x64,
+	0x49, 0x74, 0x65, 0x6d, 0x12, 0x0e, 0x0a, 0x02, 0x49, 0x44, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x02, 0x49, 0x44, 0x12, 0x12, 0x0a, 0x04, 0x4e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x04, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x41, 0x6d, 0x6f, 0x75,
+	0x6e, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x04, 0x52, 0x06, 0x41, 0x6d, 0x6f, 0x75, 0x6e, 0x74,
+	0x22, 0xa8, 0x02, 0x0a, 0x0a, 0x53, 0x61, 0x76, 0x65, 0x64, 0x42, 0x75, 0x69, 0x6c, 0x64, 0x12,
+	0x2f, 0x0a, 0x07, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x15, 0x2e, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x2e, 0x42, 0x75, 0x69, 0x6c, 0x64,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x52, 0x07, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x12, 0x32, 0x0a, 0x06, 0x46, 0x69, 0x72, 0x65, 0x41, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62,
+	0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x06, 0x46, 0x69,
+	0x72, 0x65, 0x41, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x57, 0x61, 0x6b, 0x65, 0x49, 0x44, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x57, 0x61, 0x6b, 0x65, 0x49, 0x44, 0x12, 0x31, 0x0a, 0x06,
+	0x53, 0x70, 0x65, 0x64, 0x55, 0x70, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x67,
+	0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x44,
+	0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x06, 0x53, 0x70, 0x65, 0x64, 0x55, 0x70, 0x12,
+	0x31, 0x0a, 0x04, 0x43, 0x6f, 0x73, 0x74, 0x18, 0x05, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1d, 0x2e,
+	0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x2e, 0x53, 0x61, 0x76, 0x65, 0x64, 0x42, 0x75, 0x69,
+	0x6c, 0x64, 0x2e, 0x43, 0x6f, 0x73, 0x74, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x04, 0x43, 0x6f,
+	0x73, 0x74, 0x1a, 0x37, 0x0a, 0x09, 0x43, 0x6f, 0x73, 0x74, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12,
+	0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65,
+	0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04,
+	0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0xb7, 0x01, 0x0a, 0x0a,
+	0x53, 0x61, 0x76, 0x65, 0x64, 0x43, 0x72, 0x61, 0x66, 0x74, 0x12, 0x2f, 0x0a, 0x07, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x6d, 0x65,
+	0x73, 0x73, 0x61, 0x67, 0x65, 0x2e, 0x43, 0x72, 0x61, 0x66, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x52, 0x07, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x2c, 0x0a, 0x06, 0x52,
+	0x65, 0x63, 0x69, 0x70, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x6d, 0x65,
+	0x73, 0x73, 0x61, 0x67, 0x65, 0x2e, 0x53, 0x61, 0x76, 0x65, 0x64, 0x52, 0x65, 0x63, 0x69, 0x70,
+	0x65, 0x52, 0x06, 0x52, 0x65, 0x63, 0x69, 0x70, 0x65, 0x12, 0x32, 0x0a, 0x06, 0x46, 0x69, 0x72,
+	0x65, 0x41, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67,
 	0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65,
-	0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x09, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70,
-	0x12, 0x1a, 0x0a, 0x08, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x18, 0x03, 0x20, 0x01,
-	0x28, 0x09, 0x52, 0x08, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x42, 0x2e, 0x5a, 0x2c,
-	0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x67, 0x6e, 0x61, 0x72, 0x6c,
-	0x6f, 0x71, 0x67, 0x61, 0x6d, 0x65, 0x73, 0x2f, 0x67, 0x61, 0x2d, 0x61, 0x63, 0x74, 0x6f, 0x72,
-	0x2d, 0x70, 0x6f, 0x63, 0x2f, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x62, 0x06, 0x70, 0x72,
-	0x6f, 0x74, 0x6f, 0x33,
+	0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x06, 0x46, 0x69, 0x72, 0x65, 0x41, 0x74, 0x12, 0x16, 0x0a,
+	0x06, 0x57, 0x61, 0x6b, 0x65, 0x49, 0x44, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x57,
+	0x61, 0x6b, 0x65, 0x49, 0x44, 0x22, 0xe2, 0x02, 0x0a, 0x0b, 0x53, 0x61, 0x76, 0x65, 0x64, 0x52,
+	0x65, 0x63, 0x69, 0x70, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x4e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x04, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x42, 0x75, 0x69,
+	0x6c, 0x64, 0x69, 0x6e, 0x67, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x42, 0x75, 0x69,
+	0x6c, 0x64, 0x69, 0x6e, 0x67, 0x12, 0x38, 0x0a, 0x06, 0x49, 0x6e, 0x70, 0x75, 0x74, 0x73, 0x18,
+	0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x20, 0x2e, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x2e,
+	0x53, 0x61, 0x76, 0x65, 0x64, 0x52, 0x65, 0x63, 0x69, 0x70, 0x65, 0x2e, 0x49, 0x6e, 0x70, 0x75,
+	0x74, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x06, 0x49, 0x6e, 0x70, 0x75, 0x74, 0x73, 0x12,
+	0x3b, 0x0a, 0x07, 0x4f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x0b,
+	0x32, 0x21, 0x2e, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x2e, 0x53, 0x61, 0x76, 0x65, 0x64,
+	0x52, 0x65, 0x63, 0x69, 0x70, 0x65, 0x2e, 0x4f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x73, 0x45, 0x6e,
+	0x74, 0x72, 0x79, 0x52, 0x07, 0x4f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x73, 0x12, 0x35, 0x0a, 0x08,
+	0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19,
+	0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66,
+	0x2e, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x08, 0x44, 0x75, 0x72, 0x61, 0x74,
+	0x69, 0x6f, 0x6e, 0x1a, 0x39, 0x0a, 0x0b, 0x49, 0x6e, 0x70, 0x75, 0x74, 0x73, 0x45, 0x6e, 0x74,
+	0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x04, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x1a, 0x3a,
+	0x0a, 0x0c, 0x4f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10,
+	0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79,
+	0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52,
+	0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x32, 0x8b, 0x02, 0x0a, 0x09, 0x49,
+	0x6e, 0x76, 0x65, 0x6e, 0x74, 0x6f, 0x72, 0x79, 0x12, 0x36, 0x0a, 0x05, 0x42, 0x75, 0x69, 0x6c,
+	0x64, 0x12, 0x15, 0x2e, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x2e, 0x42, 0x75, 0x69, 0x6c,
+	0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x16, 0x2e, 0x6d, 0x65, 0x73, 0x73, 0x61,
+	0x67, 0x65, 0x2e, 0x42, 0x75, 0x69, 0x6c, 0x64, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x12, 0x3a, 0x0a, 0x07, 0x53, 0x70, 0x65, 0x65, 0x64, 0x55, 0x70, 0x12, 0x17, 0x2e, 0x6d, 0x65,
+	0x73, 0x73, 0x61, 0x67, 0x65, 0x2e, 0x53, 0x70, 0x65, 0x65, 0x64, 0x55, 0x70, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x1a, 0x16, 0x2e, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x2e, 0x42,
+	0x75, 0x69, 0x6c, 0x64, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x36, 0x0a, 0x05,
+	0x43, 0x72, 0x61, 0x66, 0x74, 0x12, 0x15, 0x2e, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x2e,
+	0x43, 0x72, 0x61, 0x66, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x16, 0x2e, 0x6d,
+	0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x2e, 0x42, 0x75, 0x69, 0x6c, 0x64, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x12, 0x43, 0x0a, 0x0e, 0x51, 0x75, 0x65, 0x72, 0x79, 0x4d, 0x6f, 0x64,
+	0x69, 0x66, 0x69, 0x65, 0x72, 0x73, 0x12, 0x16, 0x2e, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65,
+	0x2e, 0x4d, 0x6f, 0x64, 0x69, 0x66, 0x69, 0x65, 0x72, 0x51, 0x75, 0x65, 0x72, 0x79, 0x1a, 0x19,
+	0x2e, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x2e, 0x4d, 0x6f, 0x64, 0x69, 0x66, 0x69, 0x65,
+	0x72, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x1a, 0x0d, 0x82, 0xb5, 0x18, 0x09, 0x69,
+	0x6e, 0x76, 0x65, 0x6e, 0x74, 0x6f, 0x72, 0x79, 0x42, 0x2e, 0x5a, 0x2c, 0x67, 0x69, 0x74, 0x68,
+	0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x67, 0x6e, 0x61, 0x72, 0x6c, 0x6f, 0x71, 0x67, 0x61,
+	0x6d, 0x65, 0x73, 0x2f, 0x67, 0x61, 0x2d, 0x61, 0x63, 0x74, 0x6f, 0x72, 0x2d, 0x70, 0x6f, 0x63,
+	0x2f, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -222,22 +1502,81 @@ func file_application_proto_rawDescGZIP() []byte {
 	return file_application_proto_rawDescData
 }
 
-var file_application_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
-var file_application_proto_goTypes = []interface{}{
+var file_application_proto_msgTypes = make([]protoimpl.MessageInfo, 21)
+var file_application_proto_goTypes = []any{
 	(*BuildRequest)(nil),          // 0: message.BuildRequest
 	(*BuildResponse)(nil),         // 1: message.BuildResponse
-	(*timestamppb.Timestamp)(nil), // 2: google.protobuf.Timestamp
-	(*structpb.Struct)(nil),       // 3: google.protobuf.Struct
+	(*SpeedUpRequest)(nil),        // 2: message.SpeedUpRequest
+	(*Modifier)(nil),              // 3: message.Modifier
+	(*ModifierEffect)(nil),        // 4: message.ModifierEffect
+	(*ModifierQuery)(nil),         // 5: message.ModifierQuery
+	(*ModifierResponse)(nil),      // 6: message.ModifierResponse
+	(*CraftRequest)(nil),          // 7: message.CraftRequest
+	(*BuildingCompleted)(nil),     // 8: message.BuildingCompleted
+	(*BuildTimerFired)(nil),       // 9: message.BuildTimerFired
+	(*CraftTimerFired)(nil),       // 10: message.CraftTimerFired
+	(*InventorySnapshot)(nil),     // 11: message.InventorySnapshot
+	(*SavedItem)(nil),             // 12: message.SavedItem
+	(*SavedBuild)(nil),            // 13: message.SavedBuild
+	(*SavedCraft)(nil),            // 14: message.SavedCraft
+	(*SavedRecipe)(nil),           // 15: message.SavedRecipe
+	nil,                           // 16: message.BuildRequest.CostEntry
+	nil,                           // 17: message.ModifierResponse.CostEntry
+	nil,                           // 18: message.SavedBuild.CostEntry
+	nil,                           // 19: message.SavedRecipe.InputsEntry
+	nil,                           // 20: message.SavedRecipe.OutputsEntry
+	(*timestamppb.Timestamp)(nil), // 21: google.protobuf.Timestamp
+	(*structpb.Struct)(nil),       // 22: google.protobuf.Struct
+	(*Address)(nil),               // 23: message.Address
+	(*durationpb.Duration)(nil),   // 24: google.protobuf.Duration
 }
 var file_application_proto_depIdxs = []int32{
-	2, // 0: message.BuildRequest.Timestamp:type_name -> google.protobuf.Timestamp
-	3, // 1: message.BuildRequest.Context:type_name -> google.protobuf.Struct
-	2, // 2: message.BuildResponse.Timestamp:type_name -> google.protobuf.Timestamp
-	3, // [3:3] is the sub-list for method output_type
-	3, // [3:3] is the sub-list for method input_type
-	3, // [3:3] is the sub-list for extension type_name
-	3, // [3:3] is the sub-list for extension extendee
-	0, // [0:3] is the sub-list for field type_name
+	21, // 0: message.BuildRequest.Timestamp:type_name -> google.protobuf.Timestamp
+	22, // 1: message.BuildRequest.Context:type_name -> google.protobuf.Struct
+	16, // 2: message.BuildRequest.Cost:type_name -> message.BuildRequest.CostEntry
+	21, // 3: message.BuildResponse.Timestamp:type_name -> google.protobuf.Timestamp
+	21, // 4: message.SpeedUpRequest.Timestamp:type_name -> google.protobuf.Timestamp
+	21, // 5: message.Modifier.Start:type_name -> google.protobuf.Timestamp
+	21, // 6: message.Modifier.End:type_name -> google.protobuf.Timestamp
+	21, // 7: message.ModifierQuery.Timestamp:type_name -> google.protobuf.Timestamp
+	0,  // 8: message.ModifierQuery.Build:type_name -> message.BuildRequest
+	21, // 9: message.ModifierResponse.Timestamp:type_name -> google.protobuf.Timestamp
+	3,  // 10: message.ModifierResponse.Active:type_name -> message.Modifier
+	17, // 11: message.ModifierResponse.Cost:type_name -> message.ModifierResponse.CostEntry
+	4,  // 12: message.ModifierResponse.Effects:type_name -> message.ModifierEffect
+	21, // 13: message.CraftRequest.Timestamp:type_name -> google.protobuf.Timestamp
+	22, // 14: message.CraftRequest.Context:type_name -> google.protobuf.Struct
+	23, // 15: message.BuildingCompleted.Inventory:type_name -> message.Address
+	21, // 16: message.BuildingCompleted.CompletedAt:type_name -> google.protobuf.Timestamp
+	12, // 17: message.InventorySnapshot.Buildings:type_name -> message.SavedItem
+	12, // 18: message.InventorySnapshot.Resources:type_name -> message.SavedItem
+	13, // 19: message.InventorySnapshot.Current:type_name -> message.SavedBuild
+	13, // 20: message.InventorySnapshot.BuildQueue:type_name -> message.SavedBuild
+	14, // 21: message.InventorySnapshot.Crafting:type_name -> message.SavedCraft
+	14, // 22: message.InventorySnapshot.CraftQueue:type_name -> message.SavedCraft
+	0,  // 23: message.SavedBuild.Request:type_name -> message.BuildRequest
+	21, // 24: message.SavedBuild.FireAt:type_name -> google.protobuf.Timestamp
+	24, // 25: message.SavedBuild.SpedUp:type_name -> google.protobuf.Duration
+	18, // 26: message.SavedBuild.Cost:type_name -> message.SavedBuild.CostEntry
+	7,  // 27: message.SavedCraft.Request:type_name -> message.CraftRequest
+	15, // 28: message.SavedCraft.Recipe:type_name -> message.SavedRecipe
+	21, // 29: message.SavedCraft.FireAt:type_name -> google.protobuf.Timestamp
+	19, // 30: message.SavedRecipe.Inputs:type_name -> message.SavedRecipe.InputsEntry
+	20, // 31: message.SavedRecipe.Outputs:type_name -> message.SavedRecipe.OutputsEntry
+	24, // 32: message.SavedRecipe.Duration:type_name -> google.protobuf.Duration
+	0,  // 33: message.Inventory.Build:input_type -> message.BuildRequest
+	2,  // 34: message.Inventory.SpeedUp:input_type -> message.SpeedUpRequest
+	7,  // 35: message.Inventory.Craft:input_type -> message.CraftRequest
+	5,  // 36: message.Inventory.QueryModifiers:input_type -> message.ModifierQuery
+	1,  // 37: message.Inventory.Build:output_type -> message.BuildResponse
+	1,  // 38: message.Inventory.SpeedUp:output_type -> message.BuildResponse
+	1,  // 39: message.Inventory.Craft:output_type -> message.BuildResponse
+	6,  // 40: message.Inventory.QueryModifiers:output_type -> message.ModifierResponse
+	37, // [37:41] is the sub-list for method output_type
+	33, // [33:37] is the sub-list for method input_type
+	33, // [33:33] is the sub-list for extension type_name
+	33, // [33:33] is the sub-list for extension extendee
+	0,  // [0:33] is the sub-list for field type_name
 }
 
 func init() { file_application_proto_init() }
@@ -245,8 +1584,10 @@ func file_application_proto_init() {
 	if File_application_proto != nil {
 		return
 	}
+	file_actor_proto_init()
+	file_options_proto_init()
 	if !protoimpl.UnsafeEnabled {
-		file_application_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+		file_application_proto_msgTypes[0].Exporter = func(v any, i int) any {
 			switch v := v.(*BuildRequest); i {
 			case 0:
 				return &v.state
@@ -258,7 +1599,7 @@ func file_application_proto_init() {
 				return nil
 			}
 		}
-		file_application_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+		file_application_proto_msgTypes[1].Exporter = func(v any, i int) any {
 			switch v := v.(*BuildResponse); i {
 			case 0:
 				return &v.state
@@ -270,6 +1611,174 @@ func file_application_proto_init() {
 				return nil
 			}
 		}
+		file_application_proto_msgTypes[2].Exporter = func(v any, i int) any {
+			switch v := v.(*SpeedUpRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_application_proto_msgTypes[3].Exporter = func(v any, i int) any {
+			switch v := v.(*Modifier); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_application_proto_msgTypes[4].Exporter = func(v any, i int) any {
+			switch v := v.(*ModifierEffect); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_application_proto_msgTypes[5].Exporter = func(v any, i int) any {
+			switch v := v.(*ModifierQuery); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_application_proto_msgTypes[6].Exporter = func(v any, i int) any {
+			switch v := v.(*ModifierResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_application_proto_msgTypes[7].Exporter = func(v any, i int) any {
+			switch v := v.(*CraftRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_application_proto_msgTypes[8].Exporter = func(v any, i int) any {
+			switch v := v.(*BuildingCompleted); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_application_proto_msgTypes[9].Exporter = func(v any, i int) any {
+			switch v := v.(*BuildTimerFired); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_application_proto_msgTypes[10].Exporter = func(v any, i int) any {
+			switch v := v.(*CraftTimerFired); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_application_proto_msgTypes[11].Exporter = func(v any, i int) any {
+			switch v := v.(*InventorySnapshot); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_application_proto_msgTypes[12].Exporter = func(v any, i int) any {
+			switch v := v.(*SavedItem); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_application_proto_msgTypes[13].Exporter = func(v any, i int) any {
+			switch v := v.(*SavedBuild); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_application_proto_msgTypes[14].Exporter = func(v any, i int) any {
+			switch v := v.(*SavedCraft); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_application_proto_msgTypes[15].Exporter = func(v any, i int) any {
+			switch v := v.(*SavedRecipe); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
 	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
@@ -277,9 +1786,9 @@ func file_application_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_application_proto_rawDesc,
 			NumEnums:      0,
-			NumMessages:   2,
+			NumMessages:   21,
 			NumExtensions: 0,
-			NumServices:   0,
+			NumServices:   1,
 		},
 		GoTypes:           file_application_proto_goTypes,
 		DependencyIndexes: file_application_proto_depIdxs,
@@ -1,7 +1,12 @@
 #!/bin/sh
+go build -o ./protoc-gen-actor ../cmd/protoc-gen-actor
+
 protoc --go_out=. --go_opt=paths=source_relative \
-    --go-grpc_out=. --go-grpc_opt=paths=source_relative \
+    --plugin=protoc-gen-actor=./protoc-gen-actor \
+    --actor_out=. --actor_opt=paths=source_relative \
     --proto_path=. *.proto
 
+rm ./protoc-gen-actor
+
 sed -i "" -e "s/,omitempty//g" ./*.go
-goimports -w .
\ No newline at end of file
+goimports -w . ./actorpb